@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"sort"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command for managing the config file
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the typtea config file",
+	Long: `Read and change defaults stored in the typtea config file.
+Each setting can also be overridden with a TYPTEA_* environment variable,
+and command-line flags take precedence over both.`,
+	Example: `  typtea config path
+  typtea config get
+  typtea config get duration
+  typtea config set language rust
+  typtea config set keys.restart tab`,
+}
+
+// configPathCmd prints the location of the config file
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Print the config file location",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+		cmd.Println(path)
+		return nil
+	},
+}
+
+// configGetCmd prints one or all settings, including environment overrides
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the effective value of a setting, or all settings",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			value, err := cfg.Get(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.Println(value)
+			return nil
+		}
+
+		for _, key := range config.Keys() {
+			value, _ := cfg.Get(key)
+			cmd.Printf("%-11s %-10s %s\n", key, value, "($"+config.EnvVar(key)+")")
+		}
+		actions := make([]string, 0, len(cfg.Keys))
+		for action := range cfg.Keys {
+			actions = append(actions, action)
+		}
+		sort.Strings(actions)
+		for _, action := range actions {
+			cmd.Printf("%-11s %s\n", "keys."+action, cfg.Keys[action])
+		}
+		return nil
+	},
+}
+
+// configSetCmd validates a value and writes it to the config file
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting in the config file",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := config.Path()
+		if err != nil {
+			return err
+		}
+
+		// Read the file alone so environment overrides aren't persisted
+		cfg, err := config.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := cfg.Set(args[0], args[1]); err != nil {
+			return err
+		}
+		if err := config.Save(path, cfg); err != nil {
+			return err
+		}
+
+		value, _ := cfg.Get(args[0])
+		cmd.Printf("%s = %s\n", args[0], value)
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPathCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/emacs"
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var (
+	emacsCount int  // Number of prompts per round
+	emacsReset bool // Forget saved chord stats
+)
+
+// emacsCmd represents the emacs command for the Emacs chord trainer
+var emacsCmd = &cobra.Command{
+	Use:   "emacs",
+	Short: "Practice Emacs key chords",
+	Long: `Get prompted for Emacs commands such as kill-line or save-buffer and press
+their default key sequence, like C-k or C-x C-s. Commands come from the Emacs Lisp
+language pack, topped up with the standard editing and file commands. Timing and
+accuracy are kept per command across sessions.`,
+	Example: `  typtea emacs
+  typtea emacs --count 40
+  typtea emacs --reset`,
+	RunE: runEmacs,
+}
+
+func init() {
+	emacsCmd.Flags().IntVarP(&emacsCount, "count", "n", 20, "Number of prompts per round (1-200)")
+	emacsCmd.Flags().BoolVar(&emacsReset, "reset", false, "Forget saved chord stats")
+}
+
+// runEmacs loads chord stats and runs the trainer
+func runEmacs(cmd *cobra.Command, args []string) error {
+	if emacsCount < 1 || emacsCount > 200 {
+		return fmt.Errorf("count must be between 1 and 200 (e.g., --count 20)")
+	}
+
+	path, err := emacs.DefaultPath()
+	if err != nil {
+		return err
+	}
+	stats, err := emacs.Load(path)
+	if err != nil {
+		return err
+	}
+	if emacsReset {
+		stats.Reset()
+		if err := stats.Save(); err != nil {
+			return err
+		}
+		cmd.Println("Chord stats cleared.")
+		return nil
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	if err := applyTheme(cfg.Theme); err != nil {
+		return err
+	}
+
+	words, err := game.NewLanguageManager().LoadLanguage("emacs")
+	if err != nil {
+		return err
+	}
+	commands := emacs.Commands(words)
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	trainer := tui.NewEmacs(tui.EmacsOptions{
+		Prompts: func() []emacs.Command { return emacs.Prompts(commands, emacsCount, rng) },
+		Stats:   stats,
+	})
+
+	p := tea.NewProgram(trainer, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI program: %w", err)
+	}
+	return nil
+}
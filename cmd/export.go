@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/history"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportFormat string // Output format for exported results
+	exportSince  string // Only export results on or after this date
+	exportOut    string // Output file path, stdout if empty
+)
+
+// exportCmd represents the export command for saved results
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export your typing history",
+	Long:  "Export saved typing test results as CSV, JSON or a Markdown table",
+	Example: `  typtea export --format csv --out results.csv
+  typtea export --format md --since 2026-01-01
+  typtea export -f json`,
+	RunE: runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "csv", "Export format ("+strings.Join(history.ExportFormats, ", ")+")")
+	exportCmd.Flags().StringVar(&exportSince, "since", "", "Only export results from this date onward (YYYY-MM-DD)")
+	exportCmd.Flags().StringVarP(&exportOut, "out", "o", "", "Write to this file instead of stdout")
+}
+
+// runExport writes the filtered history to stdout or the requested file
+func runExport(cmd *cobra.Command, args []string) error {
+	// Validate format before touching the output file
+	exportFormat = strings.ToLower(exportFormat)
+	if !slices.Contains(history.ExportFormats, exportFormat) {
+		return fmt.Errorf("unknown export format '%s' (available: %s)", exportFormat, strings.Join(history.ExportFormats, ", "))
+	}
+
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	if exportSince != "" {
+		since, err := time.ParseInLocation(time.DateOnly, exportSince, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid --since date '%s' (expected YYYY-MM-DD)", exportSince)
+		}
+		records = history.FilterSince(records, since)
+	}
+
+	if exportOut == "" {
+		return history.Export(cmd.OutOrStdout(), records, exportFormat)
+	}
+
+	file, err := os.Create(exportOut)
+	if err != nil {
+		return fmt.Errorf("could not create output file: %w", err)
+	}
+
+	if err := history.Export(file, records, exportFormat); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("could not write output file: %w", err)
+	}
+	cmd.PrintErrf("Exported %d results to %s\n", len(records), exportOut)
+	return nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/history"
+
+	"github.com/spf13/cobra"
+)
+
+var importSource string // Name recorded as the origin of imported results
+
+// importCmd represents the import command for results from other typing tools
+var importCmd = &cobra.Command{
+	Use:   "import <file.csv>...",
+	Short: "Import results from other typing tools",
+	Long: `Import results exported as CSV from web typing tests into your typtea history.
+The CSV needs wpm, acc and timestamp columns; mode and language are optional.
+Re-importing the same file skips results that are already saved.`,
+	Example: `  typtea import results.csv
+  typtea import --source monkeytype export.csv`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importSource, "source", "", "Name of the tool the results came from (defaults to the file name)")
+}
+
+// runImport parses each file and appends the results that aren't already in history
+func runImport(cmd *cobra.Command, args []string) error {
+	store, err := history.OpenDefault()
+	if err != nil {
+		return err
+	}
+	existing, err := store.Load()
+	if err != nil {
+		return err
+	}
+
+	langManager := game.NewLanguageManager()
+	resolve := func(name string) (string, bool) {
+		return resolveImportLanguage(langManager, name)
+	}
+
+	var incoming []history.Record
+	for _, path := range args {
+		source := importSource
+		if source == "" {
+			source = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("could not open %s: %w", path, err)
+		}
+		records, err := history.ImportCSV(file, source, resolve)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("could not import %s: %w", path, err)
+		}
+		incoming = append(incoming, records...)
+	}
+
+	fresh, duplicates := history.Deduplicate(existing, incoming)
+	if err := store.AppendAll(fresh); err != nil {
+		return err
+	}
+
+	tagged := 0
+	for _, r := range fresh {
+		if r.Language == history.LanguageOther || r.Mode == history.ModeOther {
+			tagged++
+		}
+	}
+
+	cmd.Printf("Imported %d results (%d duplicates skipped", len(fresh), duplicates)
+	if tagged > 0 {
+		cmd.Printf(", %d tagged with an unknown language or mode", tagged)
+	}
+	cmd.Println(")")
+	return nil
+}
+
+// resolveImportLanguage maps names like "english_1k" or "code_python" onto typtea languages
+func resolveImportLanguage(lm *game.LanguageManager, name string) (string, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.TrimPrefix(name, "code_")
+
+	if strings.HasPrefix(name, "english") {
+		return "en", true
+	}
+	if lm.IsLanguageAvailable(name) {
+		return name, true
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/learn"
+	"github.com/ashish0kumar/typtea/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var (
+	learnDuration int    // Length of each drill in seconds
+	learnReset    bool   // Forget saved lesson progress
+	learnLayout   string // Keyboard layout the lessons teach
+)
+
+// learnCmd represents the learn command for the beginner course
+var learnCmd = &cobra.Command{
+	Use:   "learn",
+	Short: "Learn to touch type with a step-by-step course",
+	Long: `Work through lessons that each add a few keys: home row, top row, bottom row,
+numbers, symbols and the symbols used in code. Pass a lesson's speed and accuracy
+targets to unlock the next one. Lessons follow the emulated layout from the config
+file unless --layout is given.`,
+	Example: `  typtea learn
+  typtea learn --duration 60
+  typtea learn --layout colemak
+  typtea learn --reset`,
+	RunE: runLearn,
+}
+
+func init() {
+	learnCmd.Flags().IntVarP(&learnDuration, "duration", "d", 30, "Drill duration in seconds (10-300)")
+	learnCmd.Flags().StringVar(&learnLayout, "layout", "", "Keyboard layout to learn, emulated on a QWERTY keyboard (built-in name or TOML file)")
+	learnCmd.Flags().BoolVar(&learnReset, "reset", false, "Forget saved lesson progress and start over")
+}
+
+// runLearn loads lesson progress and runs the course
+func runLearn(cmd *cobra.Command, args []string) error {
+	if learnDuration < 10 || learnDuration > 300 {
+		return fmt.Errorf("duration must be between 10 and 300 seconds (e.g., --duration 60)")
+	}
+
+	// Drills share the start command's settings from the config file
+	opts, _, err := buildOptions(startCmd)
+	if err != nil {
+		return err
+	}
+	opts.Duration = learnDuration
+	if learnLayout != "" {
+		layout, err := keyboard.Resolve(learnLayout)
+		if err != nil {
+			return err
+		}
+		opts.Layout, opts.Keyboard = layout, layout
+	}
+
+	path, err := learn.DefaultPath()
+	if err != nil {
+		return err
+	}
+	progress, err := learn.Load(path)
+	if err != nil {
+		return err
+	}
+	if learnReset {
+		progress.Reset()
+		if err := progress.Save(); err != nil {
+			return err
+		}
+		cmd.Println("Lesson progress cleared.")
+		return nil
+	}
+
+	dictionary, err := game.NewLanguageManager().LoadLanguage("en")
+	if err != nil {
+		return err
+	}
+
+	course := tui.NewLearn(tui.LearnOptions{
+		Test:       opts,
+		Lessons:    learn.Curriculum(opts.Layout),
+		Progress:   progress,
+		Dictionary: dictionary,
+	})
+
+	p := tea.NewProgram(course, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI program: %w", err)
+	}
+	return nil
+}
@@ -3,7 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
 
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/tui"
+
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 )
 
@@ -17,14 +23,58 @@ var rootCmd = &cobra.Command{
 	Use:   "typtea",
 	Short: "A minimal typing speed test in your terminal",
 	Long: `A terminal-based typing speed test application.
-	Supports multiple programming languages like Python, JavaScript, Go, and more.`,
-	Example: `  typtea start --lang python
+	Supports multiple programming languages like Python, JavaScript, Go, and more.
+	Run without a subcommand to pick a mode, duration and language from a menu.`,
+	Example: `  typtea
+	typtea start --lang python
 	typtea start --duration 30 --lang javascript
 	typtea start --list-langs`,
-	Run: func(cmd *cobra.Command, args []string) {
-		// Show help if no subcommands or flags are provided
-		cmd.Help()
-	},
+	RunE: runMenu,
+}
+
+// recentLimit is the number of recently used languages offered on the home screen
+const recentLimit = 5
+
+// runMenu shows the interactive home screen and launches the chosen test
+func runMenu(cmd *cobra.Command, args []string) error {
+	opts, _, err := buildOptions(startCmd)
+	if err != nil {
+		return err
+	}
+
+	// The settings page edits the file itself, without environment overrides
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	fileConfig, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// Recent languages come from the history, newest first
+	var recent []string
+	if records, err := loadHistory(); err == nil {
+		for i := len(records) - 1; i >= 0 && len(recent) < recentLimit; i-- {
+			if lang := records[i].Language; !slices.Contains(recent, lang) {
+				recent = append(recent, lang)
+			}
+		}
+	}
+
+	menu := tui.NewMenu(tui.MenuOptions{
+		Test:       opts,
+		Languages:  game.NewLanguageManager().GetAvailableLanguages(),
+		Recent:     recent,
+		Config:     fileConfig,
+		ConfigPath: path,
+	})
+
+	p := tea.NewProgram(menu, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI program: %w", err)
+	}
+	return nil
 }
 
 // versionCmd prints the current version of typtea
@@ -53,6 +103,15 @@ func init() {
 
 	// Add your subcommands
 	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(learnCmd)
+	rootCmd.AddCommand(vimCmd)
+	rootCmd.AddCommand(emacsCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(shareCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(themesCmd)
 	rootCmd.AddCommand(versionCmd)
 
 	// Check for version flag early and exit if set
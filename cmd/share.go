@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/history"
+	"github.com/ashish0kumar/typtea/internal/share"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	shareDir       string // Directory the card files are written to
+	shareClipboard bool   // Copy the text card to the clipboard over OSC52
+)
+
+// shareCmd represents the share command for rendering result cards
+var shareCmd = &cobra.Command{
+	Use:   "share [result-id]",
+	Short: "Render a shareable card of a result",
+	Long: `Render a saved result as a self-contained SVG image and an ANSI text card.
+Without a result ID, the most recent result is used. IDs are listed by 'typtea export'.`,
+	Example: `  typtea share
+  typtea share m1x2y3z4 --out-dir ~/Pictures
+  typtea share --clipboard`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runShare,
+}
+
+func init() {
+	shareCmd.Flags().StringVarP(&shareDir, "out-dir", "o", ".", "Directory to write the card files to")
+	shareCmd.Flags().BoolVarP(&shareClipboard, "clipboard", "c", false, "Copy the text card to the clipboard (OSC52)")
+}
+
+// runShare writes the card files for a result and prints the text card
+func runShare(cmd *cobra.Command, args []string) error {
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("no results saved yet")
+	}
+
+	// Color the text card with the configured theme
+	if cfg, err := config.Load(); err == nil {
+		if err := applyTheme(cfg.Theme); err != nil {
+			return err
+		}
+	}
+
+	record := records[len(records)-1]
+	if len(args) == 1 {
+		var ok bool
+		if record, ok = history.Find(records, args[0]); !ok {
+			return fmt.Errorf("no result with ID '%s'", args[0])
+		}
+	}
+
+	svgPath, ansiPath, err := share.WriteFiles(record, shareDir)
+	if err != nil {
+		return err
+	}
+
+	cmd.Println(share.ANSI(record))
+	cmd.Printf("Saved %s and %s\n", svgPath, ansiPath)
+
+	if shareClipboard {
+		if err := share.CopyToClipboard(os.Stdout, share.Plain(record)); err != nil {
+			return fmt.Errorf("could not copy card to clipboard: %w", err)
+		}
+		cmd.Println("Copied card to clipboard")
+	}
+	return nil
+}
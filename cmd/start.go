@@ -2,9 +2,15 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/ashish0kumar/typtea/internal/config"
 	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/history"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/keymap"
 	"github.com/ashish0kumar/typtea/internal/tui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,58 +18,149 @@ import (
 )
 
 var (
-	duration int    // Duration of the typing test in seconds
-	language string // Language for the typing test, default is "en"
-	listLangs bool  // Flag to list all available languages
+	duration     int    // Duration of the typing test in seconds
+	language     string // Language for the typing test, default is "en"
+	listLangs    bool   // Flag to list all available languages
+	keyboardName string // Keyboard layout used for the results heatmap
+	mode         string // Test mode
+	caret        string // Caret style
+	liveStats    bool   // Show WPM and accuracy while typing
+	backspace    string // Backspace policy
+	themeName    string // Color theme
+	confirmQuit  bool   // Ask before quitting
+	asciiFold    bool   // Accept unaccented letters for accented ones
+	highlight    bool   // Color code by syntax
+	indent       string // Indentation policy for multi-line code
+	tabWidth     int    // Spaces per tab
+	minLen       int    // Shortest word to type
+	maxLen       int    // Longest word to type
+	includeChars string // Only words with one of these characters
+	excludeChars string // Only words without any of these characters
+	matchPattern string // Only words matching this regular expression
+	difficulty   string // How strictly mistakes are punished
+	visibility   string // How much of the text is shown while typing
+	funbox       string // Comma-separated text transforms
+	layoutName   string // Keyboard layout to emulate
 )
 
 // startCmd represents the start command for the typing test
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start a typing test",
-	Long:  "Start a new typing test session with customizable duration and language",
+	Long: `Start a new typing test session with customizable duration and language.
+Defaults come from the config file (see 'typtea config path'); flags override it.`,
 	Example: `  typtea start --duration 60 --lang python
   typtea start -d 30 -l javascript
   typtea start --lang go
+  typtea start --lang go:3,sql:1
+  typtea start --min-len 6 --exclude-chars z
+  typtea start --difficulty master
+  typtea start --visibility memory
+  typtea start --funbox caps,leet
+  typtea start --lang de --ascii-fold
+  typtea start --lang bash --mode shell --indent tab
+  typtea start --layout colemak-dh
   typtea start --list-langs`,
 	RunE: runTypingTest,
 }
 
 func init() {
 	startCmd.Flags().IntVarP(&duration, "duration", "d", 30, "Test duration in seconds (10-300)")
-	startCmd.Flags().StringVarP(&language, "lang", "l", "en", "Language for typing test")
+	startCmd.Flags().StringVarP(&language, "lang", "l", "en", "Language for typing test, or a weighted mix like go:3,sql:1")
 	startCmd.Flags().BoolVar(&listLangs, "list-langs", false, "List all available languages")
+	startCmd.Flags().StringVarP(&keyboardName, "keyboard", "k", "qwerty", "Keyboard layout for the results heatmap ("+strings.Join(keyboard.Names(), ", ")+")")
+	startCmd.Flags().StringVarP(&mode, "mode", "m", "time", "Test mode ("+strings.Join(config.Modes, ", ")+")")
+	startCmd.Flags().StringVar(&caret, "caret", "block", "Caret style ("+strings.Join(config.CaretStyles, ", ")+")")
+	startCmd.Flags().BoolVar(&liveStats, "live-stats", false, "Show WPM and accuracy while typing")
+	startCmd.Flags().StringVar(&backspace, "backspace", "allow", "Backspace policy ("+strings.Join(config.BackspacePolicy, ", ")+")")
+	startCmd.Flags().StringVar(&themeName, "theme", "default", "Color theme (see 'typtea themes')")
+	startCmd.Flags().BoolVar(&confirmQuit, "confirm-quit", false, "Press the quit key twice to quit")
+	startCmd.Flags().StringVar(&layoutName, "layout", keyboard.Physical, "Keyboard layout to emulate on a QWERTY keyboard (built-in name or TOML file)")
+	startCmd.Flags().BoolVar(&asciiFold, "ascii-fold", false, "Accept unaccented letters for accented ones (e for é)")
+	startCmd.Flags().BoolVar(&highlight, "highlight", true, "Color keywords, strings, numbers and comments in code")
+	startCmd.Flags().StringVar(&indent, "indent", "skip", "How indentation of multi-line code is typed ("+strings.Join(config.IndentPolicies, ", ")+")")
+	startCmd.Flags().IntVar(&tabWidth, "tab-width", 4, "Spaces per tab (1-8)")
+	startCmd.Flags().StringVar(&difficulty, "difficulty", "normal", "How mistakes are punished ("+strings.Join(game.Difficulties, ", ")+")")
+	startCmd.Flags().StringVar(&visibility, "visibility", "normal", "How much text is shown while typing ("+strings.Join(config.Visibilities, ", ")+")")
+	startCmd.Flags().StringVar(&funbox, "funbox", "", "Comma-separated text transforms ("+strings.Join(game.Funboxes, ", ")+")")
+	startCmd.Flags().IntVar(&minLen, "min-len", 0, "Only type words of at least this many characters")
+	startCmd.Flags().IntVar(&maxLen, "max-len", 0, "Only type words of at most this many characters")
+	startCmd.Flags().StringVar(&includeChars, "include-chars", "", "Only type words containing at least one of these characters")
+	startCmd.Flags().StringVar(&excludeChars, "exclude-chars", "", "Only type words containing none of these characters")
+	startCmd.Flags().StringVar(&matchPattern, "match", "", "Only type words matching this regular expression")
+}
+
+// applyConfig fills in every flag the user didn't set from the config file
+func applyConfig(cmd *cobra.Command, cfg config.Config) {
+	flags := cmd.Flags()
+	if !flags.Changed("duration") {
+		duration = cfg.Duration
+	}
+	if !flags.Changed("lang") {
+		language = cfg.Language
+	}
+	if !flags.Changed("mode") {
+		mode = cfg.Mode
+	}
+	if !flags.Changed("caret") {
+		caret = cfg.Caret
+	}
+	if !flags.Changed("live-stats") {
+		liveStats = cfg.LiveStats
+	}
+	if !flags.Changed("backspace") {
+		backspace = cfg.Backspace
+	}
+	if !flags.Changed("theme") {
+		themeName = cfg.Theme
+	}
+	if !flags.Changed("confirm-quit") {
+		confirmQuit = cfg.ConfirmQuit
+	}
+	if !flags.Changed("ascii-fold") {
+		asciiFold = cfg.ASCIIFold
+	}
+	if !flags.Changed("highlight") {
+		highlight = cfg.Highlight
+	}
+	if !flags.Changed("indent") {
+		indent = cfg.Indent
+	}
+	if !flags.Changed("tab-width") {
+		tabWidth = cfg.TabWidth
+	}
+	if !flags.Changed("difficulty") {
+		difficulty = cfg.Difficulty
+	}
+	if !flags.Changed("visibility") {
+		visibility = cfg.Visibility
+	}
+	if !flags.Changed("funbox") {
+		funbox = cfg.Funbox
+	}
+	if !flags.Changed("layout") {
+		layoutName = cfg.Layout
+	}
 }
 
 // runTypingTest runs the typing test or lists languages if requested
 func runTypingTest(cmd *cobra.Command, args []string) error {
-	// Initialize the language manager
-	langManager := game.NewLanguageManager()
-
 	// If --list-langs flag is set, print available languages and exit
 	if listLangs {
 		cmd.Println("Available languages:")
-		for _, lang := range langManager.GetAvailableLanguages() {
+		for _, lang := range game.NewLanguageManager().GetAvailableLanguages() {
 			cmd.Printf("  %s\n", lang)
 		}
 		return nil
 	}
 
-	// Validate duration
-	if duration < 10 || duration > 300 {
-		return fmt.Errorf("duration must be between 10 and 300 seconds (e.g., --duration 60)")
-	}
-
-	// Validate language availability
-	if !langManager.IsLanguageAvailable(language) {
-		available := langManager.GetAvailableLanguages()
-		cmd.PrintErrf("Error: Language '%s' not available.\n", language)
-		cmd.PrintErrf("Available languages: %s\n", strings.Join(available, ", "))
-		return fmt.Errorf("invalid language: %s", language)
+	opts, _, err := buildOptions(cmd)
+	if err != nil {
+		return err
 	}
 
 	// Create a new typing test model
-	model, err := tui.NewModel(duration, language)
+	model, err := tui.NewModel(opts)
 	if err != nil {
 		return fmt.Errorf("error creating typing test: %w", err)
 	}
@@ -76,3 +173,136 @@ func runTypingTest(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// buildOptions merges the config file with the start flags, validates the result
+// and applies the chosen theme
+func buildOptions(cmd *cobra.Command) (tui.Options, config.Config, error) {
+	// Load defaults from the config file, letting flags take precedence
+	cfg, err := config.Load()
+	if err != nil {
+		return tui.Options{}, cfg, fmt.Errorf("error loading config: %w", err)
+	}
+	applyConfig(cmd, cfg)
+
+	// Validate mode
+	if !slices.Contains(config.Modes, mode) {
+		return tui.Options{}, cfg, fmt.Errorf("invalid mode '%s' (available: %s)", mode, strings.Join(config.Modes, ", "))
+	}
+
+	// Validate duration
+	if duration < 10 || duration > 300 {
+		return tui.Options{}, cfg, fmt.Errorf("duration must be between 10 and 300 seconds (e.g., --duration 60)")
+	}
+
+	// Validate language availability; several may be mixed, e.g. go:3,sql:1
+	mix, err := game.ParseMix(language)
+	if err != nil {
+		return tui.Options{}, cfg, fmt.Errorf("invalid language: %w", err)
+	}
+	language = game.FormatMix(mix)
+
+	// Validate the word filters
+	filter, err := buildFilter()
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	if !filter.Empty() && mode != history.ModeTime {
+		return tui.Options{}, cfg, fmt.Errorf("word filters only apply to %s mode", history.ModeTime)
+	}
+
+	// Validate the emulated layout; the heatmap follows it unless chosen explicitly
+	layout, err := keyboard.Resolve(layoutName)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	kb, err := keyboard.Get(keyboardName)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	if !cmd.Flags().Changed("keyboard") {
+		kb = layout
+	}
+
+	// Validate caret style, backspace and indent policies, difficulty, visibility and funbox
+	caretStyle, err := tui.ParseCaretStyle(caret)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	backspacePolicy, err := game.ParseBackspacePolicy(backspace)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	indentPolicy, err := game.ParseIndentPolicy(indent)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	difficultyLevel, err := game.ParseDifficulty(difficulty)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	visibilityMode, err := tui.ParseVisibility(visibility)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	funboxes, err := game.ParseFunbox(funbox)
+	if err != nil {
+		return tui.Options{}, cfg, err
+	}
+	if len(funboxes) > 0 && mode == history.ModeShell {
+		return tui.Options{}, cfg, fmt.Errorf("funbox transforms don't apply to %s mode", history.ModeShell)
+	}
+	if tabWidth < 1 || tabWidth > 8 {
+		return tui.Options{}, cfg, fmt.Errorf("tab width must be between 1 and 8 (e.g., --tab-width 4)")
+	}
+
+	// Build the keymap, rejecting bindings that clash with typing
+	keys, err := keymap.New(cfg.Keys)
+	if err != nil {
+		return tui.Options{}, cfg, fmt.Errorf("invalid keybindings in config: %w", err)
+	}
+
+	// Load the color theme
+	if err := applyTheme(themeName); err != nil {
+		return tui.Options{}, cfg, err
+	}
+
+	return tui.Options{
+		Duration:    duration,
+		Language:    language,
+		Mode:        mode,
+		Keyboard:    kb,
+		Caret:       caretStyle,
+		LiveStats:   liveStats,
+		Backspace:   backspacePolicy,
+		Keys:        keys,
+		ConfirmQuit: confirmQuit,
+		FoldASCII:   asciiFold,
+		Highlight:   highlight,
+		Indent:      indentPolicy,
+		TabWidth:    tabWidth,
+		Filter:      filter,
+		Difficulty:  difficultyLevel,
+		Visibility:  visibilityMode,
+		Funbox:      funboxes,
+		Layout:      layout,
+	}, cfg, nil
+}
+
+// buildFilter validates the word filter flags
+func buildFilter() (game.WordFilter, error) {
+	if minLen < 0 || maxLen < 0 {
+		return game.WordFilter{}, fmt.Errorf("word lengths can't be negative")
+	}
+	if maxLen > 0 && maxLen < minLen {
+		return game.WordFilter{}, fmt.Errorf("--max-len %d is shorter than --min-len %d", maxLen, minLen)
+	}
+	filter := game.WordFilter{MinLen: minLen, MaxLen: maxLen, Include: includeChars, Exclude: excludeChars}
+	if matchPattern != "" {
+		re, err := regexp.Compile(matchPattern)
+		if err != nil {
+			return game.WordFilter{}, fmt.Errorf("invalid --match pattern: %v", err)
+		}
+		filter.Match = re
+	}
+	return filter, nil
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/history"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	showHeatmap   bool   // Render a keyboard heatmap across all saved results
+	heatmapMetric string // Metric used to color the heatmap
+	statsKeyboard string // Keyboard layout used for the heatmap
+	showAnalysis  bool   // Report confusions and slow or error-prone n-grams
+	showFingers   bool   // Report per-finger load, errors and hand alternation
+	statsJSON     bool   // Print machine-readable JSON instead of text
+	statsTop      int    // Number of entries per analysis list
+)
+
+// statsCmd represents the stats command for reviewing saved results
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show statistics from your typing history",
+	Long:  "Summarize saved typing test results and visualize weak keys across your history",
+	Example: `  typtea stats
+  typtea stats --heatmap
+  typtea stats --heatmap --metric latency --keyboard dvorak
+  typtea stats --analysis --json
+  typtea stats --fingers --keyboard colemak`,
+	RunE: runStats,
+}
+
+func init() {
+	statsCmd.Flags().BoolVar(&showHeatmap, "heatmap", false, "Render a keyboard heatmap of your history")
+	statsCmd.Flags().StringVar(&heatmapMetric, "metric", "errors", "Heatmap metric (errors, latency)")
+	statsCmd.Flags().StringVarP(&statsKeyboard, "keyboard", "k", "qwerty", "Keyboard layout for the heatmap, and for finger stats of results typed natively ("+strings.Join(keyboard.Names(), ", ")+")")
+	statsCmd.Flags().BoolVar(&showAnalysis, "analysis", false, "Show mistyped characters and the slowest and most error-prone n-grams")
+	statsCmd.Flags().BoolVar(&showFingers, "fingers", false, "Show per-finger load and errors, hand alternation and same-finger bigrams")
+	statsCmd.Flags().BoolVar(&statsJSON, "json", false, "Print output as JSON")
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "Number of entries per analysis list")
+}
+
+// runStats prints a summary of the history or renders a heatmap if requested
+func runStats(cmd *cobra.Command, args []string) error {
+	records, err := loadHistory()
+	if err != nil {
+		return err
+	}
+
+	if len(records) == 0 && !statsJSON {
+		cmd.Println("No results saved yet. Run `typtea start` to take a test.")
+		return nil
+	}
+
+	// Color terminal output with the configured theme
+	if cfg, err := config.Load(); err == nil {
+		if err := applyTheme(cfg.Theme); err != nil {
+			return err
+		}
+	}
+
+	if showAnalysis {
+		analysis := game.Analyze(history.Sessions(records), statsTop)
+		if statsJSON {
+			return printJSON(cmd, analysis)
+		}
+		cmd.Println(tui.RenderAnalysis(analysis))
+		return nil
+	}
+
+	if showFingers {
+		kb, err := keyboard.Resolve(statsKeyboard)
+		if err != nil {
+			return err
+		}
+		usage := game.ComputeFingerUsage(fingerSessions(records, kb))
+		if statsJSON {
+			return printJSON(cmd, usage)
+		}
+		cmd.Println(tui.RenderFingerUsage(usage))
+		return nil
+	}
+
+	if showHeatmap {
+		metric, err := tui.ParseHeatmapMetric(heatmapMetric)
+		if err != nil {
+			return err
+		}
+		kb, err := keyboard.Get(statsKeyboard)
+		if err != nil {
+			return err
+		}
+
+		stats := game.ComputeKeyStats(history.AllKeystrokes(records))
+		cmd.Println(tui.RenderHeatmap(stats, kb, metric))
+		return nil
+	}
+
+	summary := summarize(records)
+	if statsJSON {
+		return printJSON(cmd, summary)
+	}
+
+	cmd.Printf("tests     %d\n", summary.Tests)
+	cmd.Printf("avg wpm   %.0f\n", summary.AvgWPM)
+	cmd.Printf("best wpm  %.0f\n", summary.BestWPM)
+	cmd.Printf("avg acc   %.0f%%\n", summary.AvgAccuracy)
+	return nil
+}
+
+// fingerSessions pairs the keystrokes of each record with the layout it was
+// typed on, using fallback for records typed natively or on a layout that can
+// no longer be loaded
+func fingerSessions(records []history.Record, fallback keyboard.Layout) ([][]game.Keystroke, []keyboard.Layout) {
+	resolved := map[string]keyboard.Layout{"": fallback}
+	var sessions [][]game.Keystroke
+	var layouts []keyboard.Layout
+	for _, r := range records {
+		if len(r.Keystrokes) == 0 {
+			continue
+		}
+		kb, ok := resolved[r.Layout]
+		if !ok {
+			var err error
+			if kb, err = keyboard.Resolve(r.Layout); err != nil {
+				kb = fallback
+			}
+			resolved[r.Layout] = kb
+		}
+		sessions = append(sessions, r.Keystrokes)
+		layouts = append(layouts, kb)
+	}
+	return sessions, layouts
+}
+
+// statsSummary holds aggregate figures across the history
+type statsSummary struct {
+	Tests       int     `json:"tests"`
+	AvgWPM      float64 `json:"avg_wpm"`
+	BestWPM     float64 `json:"best_wpm"`
+	AvgAccuracy float64 `json:"avg_accuracy"`
+}
+
+// summarize computes aggregate figures for the given records
+func summarize(records []history.Record) statsSummary {
+	summary := statsSummary{Tests: len(records)}
+	if len(records) == 0 {
+		return summary
+	}
+
+	var totalWPM, totalAcc float64
+	for _, r := range records {
+		totalWPM += r.WPM
+		totalAcc += r.Accuracy
+		// Funbox results are too different to compete for the best score, and
+		// failed runs measure only the seconds before the failure
+		if r.WPM > summary.BestWPM && len(r.Funbox) == 0 && r.Failure == "" {
+			summary.BestWPM = r.WPM
+		}
+	}
+	summary.AvgWPM = totalWPM / float64(len(records))
+	summary.AvgAccuracy = totalAcc / float64(len(records))
+	return summary
+}
+
+// printJSON writes v to the command output as indented JSON
+func printJSON(cmd *cobra.Command, v any) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// loadHistory opens the default history store and reads every record
+func loadHistory() ([]history.Record, error) {
+	store, err := history.OpenDefault()
+	if err != nil {
+		return nil, err
+	}
+	return store.Load()
+}
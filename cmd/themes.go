@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/ashish0kumar/typtea/internal/theme"
+	"github.com/ashish0kumar/typtea/internal/tui"
+
+	"github.com/spf13/cobra"
+)
+
+// themesCmd represents the themes command for previewing color themes
+var themesCmd = &cobra.Command{
+	Use:   "themes [name]",
+	Short: "Preview the available color themes",
+	Long: `Preview built-in themes and your own theme files.
+Custom themes are TOML or JSON files placed in the themes directory next to the config file.
+Each color is a single value or a light/dark pair, e.g. timer = { light = "4", dark = "12" }.`,
+	Example: `  typtea themes
+  typtea themes gruvbox
+  typtea start --theme nord`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runThemes,
+}
+
+// runThemes renders a preview of one theme or all of them
+func runThemes(cmd *cobra.Command, args []string) error {
+	names := args
+	if len(names) == 0 {
+		var err error
+		if names, err = theme.Names(); err != nil {
+			return err
+		}
+	}
+
+	for i, name := range names {
+		t, err := theme.Load(name)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			cmd.Println()
+		}
+		cmd.Println(tui.RenderThemePreview(t))
+	}
+
+	if dir, err := theme.UserDir(); err == nil && len(args) == 0 {
+		cmd.Printf("\nCustom themes are loaded from %s\n", dir)
+	}
+	return nil
+}
+
+// applyTheme loads a theme by name and uses it for all TUI styles
+func applyTheme(name string) error {
+	t, err := theme.Load(name)
+	if err != nil {
+		return err
+	}
+	tui.ApplyTheme(t)
+	return nil
+}
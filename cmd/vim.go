@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/tui"
+	"github.com/ashish0kumar/typtea/internal/vim"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+)
+
+var vimTasks int // Number of tasks per round
+
+// vimCmd represents the vim command for the Vim motion trainer
+var vimCmd = &cobra.Command{
+	Use:   "vim",
+	Short: "Practice Vim motions and edits on small buffers",
+	Long: `Perform editing tasks such as "delete everything inside the parens" on a small
+buffer using real Normal mode keys. A built-in engine understands counts, motions,
+the d, c and y operators, text objects, put and undo. Each task is timed and
+compared against the fewest keystrokes that complete it. Buffers are filled
+with words from the Vimscript language pack.`,
+	Example: `  typtea vim
+  typtea vim --tasks 5`,
+	RunE: runVim,
+}
+
+func init() {
+	vimCmd.Flags().IntVarP(&vimTasks, "tasks", "n", 8, "Number of tasks per round (1-30)")
+}
+
+// runVim builds tasks from the Vimscript pack and runs the trainer
+func runVim(cmd *cobra.Command, args []string) error {
+	if vimTasks < 1 || vimTasks > 30 {
+		return fmt.Errorf("tasks must be between 1 and 30 (e.g., --tasks 8)")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("error loading config: %w", err)
+	}
+	if err := applyTheme(cfg.Theme); err != nil {
+		return err
+	}
+
+	words, err := game.NewLanguageManager().LoadLanguage("vim")
+	if err != nil {
+		return err
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	trainer := tui.NewVim(tui.VimOptions{
+		Tasks: func() []vim.Task { return vim.Tasks(words, vimTasks, rng) },
+	})
+
+	p := tea.NewProgram(trainer, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("error running TUI program: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/keymap"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds user defaults and behavior settings
+type Config struct {
+	Language    string            `toml:"language"`
+	Mode        string            `toml:"mode"`
+	Duration    int               `toml:"duration"`
+	Caret       string            `toml:"caret"`
+	LiveStats   bool              `toml:"live_stats"`
+	Backspace   string            `toml:"backspace"`
+	Theme       string            `toml:"theme"`
+	ConfirmQuit bool              `toml:"confirm_quit"`
+	ASCIIFold   bool              `toml:"ascii_fold"`
+	Highlight   bool              `toml:"highlight"` // Color code by syntax
+	Indent      string            `toml:"indent"`    // How indentation of multi-line code is typed
+	TabWidth    int               `toml:"tab_width"`
+	Difficulty  string            `toml:"difficulty"`
+	Visibility  string            `toml:"visibility"`
+	Funbox      string            `toml:"funbox"`         // Comma-separated text transforms, e.g. "caps,leet"
+	Layout      string            `toml:"layout"`         // Keyboard layout to emulate, a built-in name or a TOML file
+	Keys        map[string]string `toml:"keys,omitempty"` // Action name to key, e.g. restart = "tab"
+}
+
+// Allowed values for enumerated settings
+var (
+	Modes           = []string{"time", "symbols", "shell"}
+	CaretStyles     = []string{"block", "underline"}
+	BackspacePolicy = []string{"allow", "word", "off"}
+	IndentPolicies  = []string{"skip", "tab", "spaces"}
+	Visibilities    = []string{"normal", "blind", "memory", "hidden"}
+)
+
+// keysPrefix namespaces keybinding settings in get/set, e.g. keys.restart
+const keysPrefix = "keys."
+
+// Default returns the built-in configuration
+func Default() Config {
+	return Config{
+		Language:   "en",
+		Mode:       "time",
+		Duration:   30,
+		Caret:      "block",
+		LiveStats:  false,
+		Backspace:  "allow",
+		Theme:      "default",
+		Layout:     keyboard.Physical,
+		Highlight:  true,
+		Indent:     "skip",
+		TabWidth:   4,
+		Difficulty: "normal",
+		Visibility: "normal",
+	}
+}
+
+// setting describes a single scalar configuration key
+type setting struct {
+	env string
+	get func(c *Config) string
+	set func(c *Config, value string) error
+}
+
+// settings maps each key name to its accessors and environment override
+var settings = map[string]setting{
+	"language": {
+		env: "TYPTEA_LANGUAGE",
+		get: func(c *Config) string { return c.Language },
+		set: func(c *Config, v string) error {
+			mix, err := game.ParseMix(v)
+			if err != nil {
+				return err
+			}
+			c.Language = game.FormatMix(mix)
+			return nil
+		},
+	},
+	"mode": {
+		env: "TYPTEA_MODE",
+		get: func(c *Config) string { return c.Mode },
+		set: func(c *Config, v string) error { return setEnum(&c.Mode, v, Modes) },
+	},
+	"duration": {
+		env: "TYPTEA_DURATION",
+		get: func(c *Config) string { return strconv.Itoa(c.Duration) },
+		set: func(c *Config, v string) error {
+			d, err := strconv.Atoi(v)
+			if err != nil || d < 10 || d > 300 {
+				return fmt.Errorf("duration must be a number of seconds between 10 and 300")
+			}
+			c.Duration = d
+			return nil
+		},
+	},
+	"caret": {
+		env: "TYPTEA_CARET",
+		get: func(c *Config) string { return c.Caret },
+		set: func(c *Config, v string) error { return setEnum(&c.Caret, v, CaretStyles) },
+	},
+	"live_stats": {
+		env: "TYPTEA_LIVE_STATS",
+		get: func(c *Config) string { return strconv.FormatBool(c.LiveStats) },
+		set: func(c *Config, v string) error { return setBool(&c.LiveStats, "live_stats", v) },
+	},
+	"backspace": {
+		env: "TYPTEA_BACKSPACE",
+		get: func(c *Config) string { return c.Backspace },
+		set: func(c *Config, v string) error { return setEnum(&c.Backspace, v, BackspacePolicy) },
+	},
+	"theme": {
+		env: "TYPTEA_THEME",
+		get: func(c *Config) string { return c.Theme },
+		set: func(c *Config, v string) error { c.Theme = v; return nil },
+	},
+	"confirm_quit": {
+		env: "TYPTEA_CONFIRM_QUIT",
+		get: func(c *Config) string { return strconv.FormatBool(c.ConfirmQuit) },
+		set: func(c *Config, v string) error { return setBool(&c.ConfirmQuit, "confirm_quit", v) },
+	},
+	"layout": {
+		env: "TYPTEA_LAYOUT",
+		get: func(c *Config) string { return c.Layout },
+		set: func(c *Config, v string) error {
+			if _, err := keyboard.Resolve(v); err != nil {
+				return err
+			}
+			c.Layout = v
+			return nil
+		},
+	},
+	"ascii_fold": {
+		env: "TYPTEA_ASCII_FOLD",
+		get: func(c *Config) string { return strconv.FormatBool(c.ASCIIFold) },
+		set: func(c *Config, v string) error { return setBool(&c.ASCIIFold, "ascii_fold", v) },
+	},
+	"highlight": {
+		env: "TYPTEA_HIGHLIGHT",
+		get: func(c *Config) string { return strconv.FormatBool(c.Highlight) },
+		set: func(c *Config, v string) error { return setBool(&c.Highlight, "highlight", v) },
+	},
+	"indent": {
+		env: "TYPTEA_INDENT",
+		get: func(c *Config) string { return c.Indent },
+		set: func(c *Config, v string) error { return setEnum(&c.Indent, v, IndentPolicies) },
+	},
+	"difficulty": {
+		env: "TYPTEA_DIFFICULTY",
+		get: func(c *Config) string { return c.Difficulty },
+		set: func(c *Config, v string) error { return setEnum(&c.Difficulty, v, game.Difficulties) },
+	},
+	"visibility": {
+		env: "TYPTEA_VISIBILITY",
+		get: func(c *Config) string { return c.Visibility },
+		set: func(c *Config, v string) error { return setEnum(&c.Visibility, v, Visibilities) },
+	},
+	"funbox": {
+		env: "TYPTEA_FUNBOX",
+		get: func(c *Config) string { return c.Funbox },
+		set: func(c *Config, v string) error {
+			boxes, err := game.ParseFunbox(v)
+			if err != nil {
+				return err
+			}
+			c.Funbox = strings.Join(boxes, ",")
+			return nil
+		},
+	},
+	"tab_width": {
+		env: "TYPTEA_TAB_WIDTH",
+		get: func(c *Config) string { return strconv.Itoa(c.TabWidth) },
+		set: func(c *Config, v string) error {
+			w, err := strconv.Atoi(v)
+			if err != nil || w < 1 || w > 8 {
+				return fmt.Errorf("tab_width must be a number of spaces between 1 and 8")
+			}
+			c.TabWidth = w
+			return nil
+		},
+	},
+}
+
+// setEnum assigns value to field if it is one of the allowed options
+func setEnum(field *string, value string, allowed []string) error {
+	value = strings.ToLower(value)
+	for _, a := range allowed {
+		if a == value {
+			*field = value
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value '%s' (allowed: %s)", value, strings.Join(allowed, ", "))
+}
+
+// setBool parses a boolean setting
+func setBool(field *bool, key, value string) error {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%s must be true or false", key)
+	}
+	*field = b
+	return nil
+}
+
+// Keys returns the sorted names of all scalar settings
+func Keys() []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the value of a setting as a string
+func (c *Config) Get(key string) (string, error) {
+	if action, ok := strings.CutPrefix(key, keysPrefix); ok {
+		return c.Keys[action], nil
+	}
+	s, ok := settings[key]
+	if !ok {
+		return "", fmt.Errorf("unknown setting '%s' (available: %s, %s<action>)", key, strings.Join(Keys(), ", "), keysPrefix)
+	}
+	return s.get(c), nil
+}
+
+// Set parses and assigns the value of a setting
+func (c *Config) Set(key, value string) error {
+	if action, ok := strings.CutPrefix(key, keysPrefix); ok {
+		if !keymap.IsAction(action) {
+			return fmt.Errorf("unknown action '%s' (available: %s)", action, strings.Join(keymap.Actions(), ", "))
+		}
+		keys := map[string]string{action: value}
+		for a, k := range c.Keys {
+			if a != action {
+				keys[a] = k
+			}
+		}
+		if _, err := keymap.New(keys); err != nil {
+			return err
+		}
+		c.Keys = keys
+		return nil
+	}
+	s, ok := settings[key]
+	if !ok {
+		return fmt.Errorf("unknown setting '%s' (available: %s, %s<action>)", key, strings.Join(Keys(), ", "), keysPrefix)
+	}
+	if err := s.set(c, value); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// Path returns $XDG_CONFIG_HOME/typtea/config.toml, falling back to ~/.config
+func Path() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory: %v", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "typtea", "config.toml"), nil
+}
+
+// Load reads the config file at the default path and applies environment overrides
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Default(), err
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.applyEnv()
+}
+
+// LoadFile reads the config file at path on top of the defaults.
+// A missing file is not an error.
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("could not read config: %v", err)
+	}
+
+	// Decode into raw strings first so every value goes through the same validation as `config set`
+	var raw map[string]any
+	if err := toml.Unmarshal(data, &raw); err != nil {
+		return cfg, fmt.Errorf("could not parse %s: %v", path, err)
+	}
+	for key, value := range raw {
+		if key == "keys" {
+			table, ok := value.(map[string]any)
+			if !ok {
+				return cfg, fmt.Errorf("%s: keys must be a table", path)
+			}
+			for action, k := range table {
+				if err := cfg.Set(keysPrefix+action, fmt.Sprint(k)); err != nil {
+					return cfg, fmt.Errorf("%s: %w", path, err)
+				}
+			}
+			continue
+		}
+		if err := cfg.Set(key, fmt.Sprint(value)); err != nil {
+			return cfg, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides settings from TYPTEA_* environment variables
+func (c *Config) applyEnv() error {
+	for _, key := range Keys() {
+		if value, ok := os.LookupEnv(settings[key].env); ok && value != "" {
+			if err := c.Set(key, value); err != nil {
+				return fmt.Errorf("%s: %w", settings[key].env, err)
+			}
+		}
+	}
+	return nil
+}
+
+// EnvVar returns the environment variable that overrides a setting
+func EnvVar(key string) string {
+	return settings[key].env
+}
+
+// Save writes the config to path as TOML, creating the directory if needed
+func Save(path string, c Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("could not create config directory: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(c); err != nil {
+		return fmt.Errorf("could not encode config: %v", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("could not write config: %v", err)
+	}
+	return nil
+}
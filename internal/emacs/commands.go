@@ -0,0 +1,154 @@
+package emacs
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Command is an Emacs command and its default key binding in Emacs notation,
+// e.g. "C-x C-s" for save-buffer
+type Command struct {
+	Name string
+	Keys string
+}
+
+// bindings are default bindings a terminal can deliver. C-x C-c is left out
+// because ctrl+c quits, as are bindings on keys terminals can't tell apart,
+// such as C-i from tab.
+var bindings = []Command{
+	{"forward-char", "C-f"},
+	{"backward-char", "C-b"},
+	{"next-line", "C-n"},
+	{"previous-line", "C-p"},
+	{"beginning-of-line", "C-a"},
+	{"end-of-line", "C-e"},
+	{"forward-word", "M-f"},
+	{"backward-word", "M-b"},
+	{"beginning-of-buffer", "M-<"},
+	{"end-of-buffer", "M->"},
+	{"scroll-up-command", "C-v"},
+	{"scroll-down-command", "M-v"},
+	{"recenter-top-bottom", "C-l"},
+	{"goto-line", "M-g g"},
+	{"goto-char", "M-g c"},
+	{"delete-char", "C-d"},
+	{"kill-word", "M-d"},
+	{"kill-line", "C-k"},
+	{"kill-region", "C-w"},
+	{"kill-ring-save", "M-w"},
+	{"yank", "C-y"},
+	{"yank-pop", "M-y"},
+	{"set-mark-command", "C-SPC"},
+	{"exchange-point-and-mark", "C-x C-x"},
+	{"mark-whole-buffer", "C-x h"},
+	{"undo", "C-/"},
+	{"open-line", "C-o"},
+	{"transpose-chars", "C-t"},
+	{"upcase-word", "M-u"},
+	{"downcase-word", "M-l"},
+	{"capitalize-word", "M-c"},
+	{"comment-dwim", "M-;"},
+	{"isearch-forward", "C-s"},
+	{"isearch-backward", "C-r"},
+	{"query-replace", "M-%"},
+	{"execute-extended-command", "M-x"},
+	{"keyboard-quit", "C-g"},
+	{"find-file", "C-x C-f"},
+	{"save-buffer", "C-x C-s"},
+	{"write-file", "C-x C-w"},
+	{"switch-to-buffer", "C-x b"},
+	{"list-buffers", "C-x C-b"},
+	{"kill-buffer", "C-x k"},
+	{"other-window", "C-x o"},
+	{"delete-other-windows", "C-x 1"},
+	{"split-window-below", "C-x 2"},
+	{"split-window-right", "C-x 3"},
+	{"eval-last-sexp", "C-x C-e"},
+}
+
+// minPackCommands is the number of bound commands a pack needs before the
+// standard bindings are left out
+const minPackCommands = 12
+
+// Commands returns the commands to practice: those named in a language pack
+// that have a default binding, topped up with the standard editing and file
+// commands when the pack has few of its own
+func Commands(words []string) []Command {
+	var commands []Command
+	for _, c := range bindings {
+		if slices.Contains(words, c.Name) {
+			commands = append(commands, c)
+		}
+	}
+	if len(commands) < minPackCommands {
+		for _, c := range bindings {
+			if !slices.Contains(commands, c) {
+				commands = append(commands, c)
+			}
+		}
+	}
+	return commands
+}
+
+// Prompts draws count commands at random, never asking for the same one twice in a row
+func Prompts(commands []Command, count int, rng *rand.Rand) []Command {
+	prompts := make([]Command, 0, count)
+	for len(prompts) < count {
+		c := commands[rng.Intn(len(commands))]
+		if len(commands) > 1 && len(prompts) > 0 && prompts[len(prompts)-1] == c {
+			continue
+		}
+		prompts = append(prompts, c)
+	}
+	return prompts
+}
+
+// Sequence returns the keys of the binding as Bubble Tea names them,
+// e.g. ["ctrl+x", "ctrl+s"] for C-x C-s
+func (c Command) Sequence() []string {
+	var keys []string
+	for _, k := range strings.Fields(c.Keys) {
+		keys = append(keys, KeyName(k))
+	}
+	return keys
+}
+
+// terminalKeys maps keys whose control codes terminals report under another name
+var terminalKeys = map[string]string{
+	"C-SPC": "ctrl+@",
+	"C-/":   "ctrl+_",
+	"SPC":   " ",
+	"RET":   "enter",
+	"TAB":   "tab",
+}
+
+// KeyName converts a key in Emacs notation, like C-x or M-%, to its Bubble Tea name
+func KeyName(key string) string {
+	if name, ok := terminalKeys[key]; ok {
+		return name
+	}
+	switch {
+	case strings.HasPrefix(key, "C-"):
+		return "ctrl+" + key[2:]
+	case strings.HasPrefix(key, "M-"):
+		return "alt+" + KeyName(key[2:])
+	}
+	return key
+}
+
+// Describe converts a Bubble Tea key name back to Emacs notation
+func Describe(name string) string {
+	for key, n := range terminalKeys {
+		if n == name {
+			return key
+		}
+	}
+	switch {
+	case strings.HasPrefix(name, "alt+"):
+		return "M-" + Describe(name[4:])
+	case strings.HasPrefix(name, "ctrl+"):
+		return "C-" + name[5:]
+	}
+	return name
+}
@@ -0,0 +1,111 @@
+package emacs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/history"
+)
+
+// CommandStats accumulates attempts at a single command
+type CommandStats struct {
+	Attempts int   `json:"attempts"`
+	Correct  int   `json:"correct"`
+	TotalMs  int64 `json:"total_ms"` // Time spent on correct attempts
+	BestMs   int64 `json:"best_ms,omitempty"`
+}
+
+// Accuracy returns the percentage of attempts that pressed the right keys
+func (s CommandStats) Accuracy() float64 {
+	if s.Attempts == 0 {
+		return 0
+	}
+	return 100 * float64(s.Correct) / float64(s.Attempts)
+}
+
+// AverageTime returns the mean time of correct attempts
+func (s CommandStats) AverageTime() time.Duration {
+	if s.Correct == 0 {
+		return 0
+	}
+	return time.Duration(s.TotalMs/int64(s.Correct)) * time.Millisecond
+}
+
+// Add records one attempt
+func (s *CommandStats) Add(correct bool, elapsed time.Duration) {
+	s.Attempts++
+	if !correct {
+		return
+	}
+	ms := elapsed.Milliseconds()
+	s.Correct++
+	s.TotalMs += ms
+	if s.BestMs == 0 || ms < s.BestMs {
+		s.BestMs = ms
+	}
+}
+
+// Stats keeps timing and accuracy per command across sessions
+type Stats struct {
+	Commands map[string]CommandStats `json:"commands"`
+	path     string
+}
+
+// DefaultPath returns the stats file, stored next to the history
+func DefaultPath() (string, error) {
+	historyPath, err := history.DefaultPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(historyPath), "emacs.json"), nil
+}
+
+// Load reads stats from path, starting fresh if the file doesn't exist
+func Load(path string) (*Stats, error) {
+	s := &Stats{Commands: make(map[string]CommandStats), path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read chord stats: %v", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("could not parse chord stats %s: %v", path, err)
+	}
+	if s.Commands == nil {
+		s.Commands = make(map[string]CommandStats)
+	}
+	return s, nil
+}
+
+// Save writes stats back to their file, creating the directory if needed
+func (s *Stats) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("could not create stats directory: %v", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode chord stats: %v", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("could not write chord stats: %v", err)
+	}
+	return nil
+}
+
+// Record stores an attempt at a command
+func (s *Stats) Record(name string, correct bool, elapsed time.Duration) {
+	c := s.Commands[name]
+	c.Add(correct, elapsed)
+	s.Commands[name] = c
+}
+
+// Reset forgets every recorded attempt
+func (s *Stats) Reset() {
+	s.Commands = make(map[string]CommandStats)
+}
@@ -0,0 +1,193 @@
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+// Confusion counts how often one character was typed in place of another
+type Confusion struct {
+	Expected string `json:"expected"`
+	Typed    string `json:"typed"`
+	Count    int    `json:"count"`
+}
+
+// NgramStat aggregates timing and errors for a sequence of expected characters
+type NgramStat struct {
+	Ngram       string        `json:"ngram"`
+	Occurrences int           `json:"occurrences"`
+	Errors      int           `json:"errors"`
+	AvgLatency  time.Duration `json:"avg_latency_ns"`
+	ErrorRate   float64       `json:"error_rate"`
+}
+
+// Analysis is the result of an error and timing pass over recorded keystrokes
+type Analysis struct {
+	Confusions       []Confusion `json:"confusions"`
+	SlowestBigrams   []NgramStat `json:"slowest_bigrams"`
+	ErrorBigrams     []NgramStat `json:"error_bigrams"`
+	SlowestTrigrams  []NgramStat `json:"slowest_trigrams"`
+	ErrorTrigrams    []NgramStat `json:"error_trigrams"`
+	KeystrokesTotal  int         `json:"keystrokes_total"`
+	KeystrokesWrong  int         `json:"keystrokes_wrong"`
+	SessionsAnalyzed int         `json:"sessions_analyzed"`
+}
+
+// minNgramOccurrences filters out n-grams seen too rarely to rank meaningfully
+const minNgramOccurrences = 2
+
+// ngramAccumulator collects raw totals before they are turned into an NgramStat
+type ngramAccumulator struct {
+	occurrences  int
+	errors       int
+	totalLatency time.Duration
+	timed        int
+}
+
+// Analyze builds a confusion matrix and ranks n-grams across one or more sessions,
+// keeping at most limit entries per list
+func Analyze(sessions [][]Keystroke, limit int) Analysis {
+	confusions := make(map[[2]rune]int)
+	bigrams := make(map[string]*ngramAccumulator)
+	trigrams := make(map[string]*ngramAccumulator)
+
+	analysis := Analysis{SessionsAnalyzed: len(sessions)}
+
+	for _, keystrokes := range sessions {
+		for i, k := range keystrokes {
+			analysis.KeystrokesTotal++
+			if !k.Correct() {
+				analysis.KeystrokesWrong++
+				confusions[[2]rune{k.Expected, k.Typed}]++
+			}
+			if i >= 1 {
+				accumulateNgram(bigrams, keystrokes[i-1:i+1])
+			}
+			if i >= 2 {
+				accumulateNgram(trigrams, keystrokes[i-2:i+1])
+			}
+		}
+	}
+
+	for pair, count := range confusions {
+		analysis.Confusions = append(analysis.Confusions, Confusion{
+			Expected: string(pair[0]),
+			Typed:    string(pair[1]),
+			Count:    count,
+		})
+	}
+	sort.Slice(analysis.Confusions, func(i, j int) bool {
+		a, b := analysis.Confusions[i], analysis.Confusions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Expected+a.Typed < b.Expected+b.Typed
+	})
+	analysis.Confusions = truncate(analysis.Confusions, limit)
+
+	analysis.SlowestBigrams, analysis.ErrorBigrams = rankNgrams(bigrams, limit)
+	analysis.SlowestTrigrams, analysis.ErrorTrigrams = rankNgrams(trigrams, limit)
+
+	return analysis
+}
+
+// accumulateNgram adds a window of keystrokes to the n-gram totals, skipping
+// windows that span a word or command boundary
+func accumulateNgram(ngrams map[string]*ngramAccumulator, window []Keystroke) {
+	runes := make([]rune, len(window))
+	for i, k := range window {
+		if k.Expected == ' ' || k.Expected == '\n' || k.Expected == '\t' {
+			return
+		}
+		runes[i] = k.Expected
+	}
+
+	key := string(runes)
+	acc, ok := ngrams[key]
+	if !ok {
+		acc = &ngramAccumulator{}
+		ngrams[key] = acc
+	}
+	acc.occurrences++
+
+	if !allCorrect(window) {
+		acc.errors++
+		return
+	}
+
+	// Timing covers the transitions inside the n-gram, not the approach to its first key
+	var latency time.Duration
+	for _, k := range window[1:] {
+		if k.Latency <= 0 || k.Latency > maxKeyLatency {
+			return
+		}
+		latency += k.Latency
+	}
+	acc.totalLatency += latency
+	acc.timed++
+}
+
+// allCorrect reports whether every keystroke in the window was typed correctly
+func allCorrect(window []Keystroke) bool {
+	for _, k := range window {
+		if !k.Correct() {
+			return false
+		}
+	}
+	return true
+}
+
+// rankNgrams returns the slowest and the most error-prone n-grams
+func rankNgrams(ngrams map[string]*ngramAccumulator, limit int) (slowest, errorProne []NgramStat) {
+	var stats []NgramStat
+	for ngram, acc := range ngrams {
+		if acc.occurrences < minNgramOccurrences {
+			continue
+		}
+		stat := NgramStat{
+			Ngram:       ngram,
+			Occurrences: acc.occurrences,
+			Errors:      acc.errors,
+			ErrorRate:   float64(acc.errors) / float64(acc.occurrences),
+		}
+		if acc.timed > 0 {
+			stat.AvgLatency = acc.totalLatency / time.Duration(acc.timed)
+		}
+		stats = append(stats, stat)
+	}
+
+	for _, s := range stats {
+		if s.AvgLatency > 0 {
+			slowest = append(slowest, s)
+		}
+		if s.Errors > 0 {
+			errorProne = append(errorProne, s)
+		}
+	}
+
+	sort.Slice(slowest, func(i, j int) bool {
+		if slowest[i].AvgLatency != slowest[j].AvgLatency {
+			return slowest[i].AvgLatency > slowest[j].AvgLatency
+		}
+		return slowest[i].Ngram < slowest[j].Ngram
+	})
+	sort.Slice(errorProne, func(i, j int) bool {
+		if errorProne[i].ErrorRate != errorProne[j].ErrorRate {
+			return errorProne[i].ErrorRate > errorProne[j].ErrorRate
+		}
+		if errorProne[i].Occurrences != errorProne[j].Occurrences {
+			return errorProne[i].Occurrences > errorProne[j].Occurrences
+		}
+		return errorProne[i].Ngram < errorProne[j].Ngram
+	})
+
+	return truncate(slowest, limit), truncate(errorProne, limit)
+}
+
+// truncate limits a slice to at most n elements, treating n <= 0 as unlimited
+func truncate[T any](items []T, n int) []T {
+	if n > 0 && len(items) > n {
+		return items[:n]
+	}
+	return items
+}
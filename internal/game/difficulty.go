@@ -0,0 +1,73 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Difficulty controls how strictly mistakes are punished
+type Difficulty int
+
+const (
+	DifficultyNormal Difficulty = iota // Mistakes are marked and typing moves on
+	DifficultyExpert                   // The test fails when a word is submitted with a mistake
+	DifficultyMaster                   // The test fails on any wrong keystroke
+	DifficultyStop                     // Typing doesn't advance until the right key is pressed
+)
+
+// Difficulties lists the difficulty names in order
+var Difficulties = []string{"normal", "expert", "master", "stop-on-error"}
+
+// ParseDifficulty converts a difficulty name into a Difficulty
+func ParseDifficulty(name string) (Difficulty, error) {
+	if name == "" {
+		return DifficultyNormal, nil
+	}
+	for i, d := range Difficulties {
+		if strings.EqualFold(name, d) {
+			return Difficulty(i), nil
+		}
+	}
+	return DifficultyNormal, fmt.Errorf("unknown difficulty '%s' (available: %s)", name, strings.Join(Difficulties, ", "))
+}
+
+// String returns the difficulty name
+func (d Difficulty) String() string {
+	if d < 0 || int(d) >= len(Difficulties) {
+		return Difficulties[0]
+	}
+	return Difficulties[d]
+}
+
+// fail ends the test early, keeping the reason for the results screen
+func (g *TypingGame) fail(reason string) {
+	g.FailReason = reason
+	g.IsFinished = true
+}
+
+// submittedWord returns the word just ended with a space or at the end of the
+// line, and whether it has a mistake, including a space typed too early
+func (g *TypingGame) submittedWord() (string, bool) {
+	start := max(g.CurrentPos-1, g.lineStart)
+	for start > g.lineStart && g.lineClusters[start-1] != " " {
+		start--
+	}
+	bad := false
+	for i := start; i < g.CurrentPos; i++ {
+		bad = bad || g.HasError(i)
+	}
+	return strings.TrimSpace(strings.Join(g.lineClusters[start:g.CurrentPos], "")), bad
+}
+
+// describeKey quotes a character for a failure reason, naming invisible ones
+func describeKey(r rune) string {
+	switch r {
+	case ' ':
+		return "space"
+	case '\n':
+		return "enter"
+	case '\t':
+		return "tab"
+	}
+	return fmt.Sprintf("'%c'", r)
+}
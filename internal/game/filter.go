@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// minFilteredWords is the fewest words a filtered session may draw from
+const minFilteredWords = 10
+
+// WordFilter narrows the word list of a session before words are drawn from it
+type WordFilter struct {
+	MinLen  int            // Minimum length in characters, 0 for no limit
+	MaxLen  int            // Maximum length in characters, 0 for no limit
+	Include string         // Words must contain at least one of these characters
+	Exclude string         // Words must not contain any of these characters
+	Match   *regexp.Regexp // Words must match this pattern
+}
+
+// Empty reports whether the filter keeps every word
+func (f WordFilter) Empty() bool {
+	return f.MinLen == 0 && f.MaxLen == 0 && f.Include == "" && f.Exclude == "" && f.Match == nil
+}
+
+// Keep reports whether a word passes the filter. Characters are compared
+// without regard to case, so excluding z also drops words with Z.
+func (f WordFilter) Keep(word string) bool {
+	length := len(Graphemes(word))
+	if length < f.MinLen || (f.MaxLen > 0 && length > f.MaxLen) {
+		return false
+	}
+	lower := strings.ToLower(word)
+	if f.Include != "" && !strings.ContainsAny(lower, strings.ToLower(f.Include)) {
+		return false
+	}
+	if f.Exclude != "" && strings.ContainsAny(lower, strings.ToLower(f.Exclude)) {
+		return false
+	}
+	return f.Match == nil || f.Match.MatchString(word)
+}
+
+// SetWordFilter restricts the words of the current language, failing when
+// too few are left to generate a test from
+func SetWordFilter(f WordFilter) error {
+	if f.Empty() {
+		return nil
+	}
+	if err := currentGenerator.filter(f); err != nil {
+		return err
+	}
+	currentLanguageWords = currentGenerator.allWords()
+	return nil
+}
+
+// filter drops the words of every source that don't pass f
+func (g *Generator) filter(f WordFilter) error {
+	total := 0
+	for i, s := range g.sources {
+		var kept []string
+		for _, w := range s.words {
+			if f.Keep(w) {
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) == 0 {
+			return fmt.Errorf("no words in '%s' pass the word filters", s.code)
+		}
+		g.sources[i].words = kept
+		if s.cumulative != nil {
+			g.sources[i].cumulative = rankWeights(kept)
+		}
+		total += len(kept)
+	}
+	if total < minFilteredWords {
+		return fmt.Errorf("word filters leave only %d words (at least %d needed)", total, minFilteredWords)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package game
+
+import "github.com/ashish0kumar/typtea/internal/keyboard"
+
+// FingerStat aggregates the keystrokes typed by one finger
+type FingerStat struct {
+	Finger    keyboard.Finger `json:"finger"`
+	Presses   int             `json:"presses"`
+	Errors    int             `json:"errors"`
+	Load      float64         `json:"load"`       // Share of all mapped presses
+	ErrorRate float64         `json:"error_rate"` // Share of this finger's presses that were mistyped
+}
+
+// FingerUsage summarizes how work is spread across fingers and hands
+type FingerUsage struct {
+	Fingers           []FingerStat `json:"fingers"`
+	LeftHand          float64      `json:"left_hand"`  // Share of letter and symbol presses
+	RightHand         float64      `json:"right_hand"` // Share of letter and symbol presses
+	Bigrams           int          `json:"bigrams"`    // Consecutive key pairs within a word
+	SameFingerBigrams int          `json:"same_finger_bigrams"`
+	SameFingerRate    float64      `json:"same_finger_rate"`
+	AlternationRate   float64      `json:"alternation_rate"` // Share of bigrams that switch hands
+	Unmapped          int          `json:"unmapped"`         // Keystrokes with no key on the layout
+}
+
+// ComputeFingerUsage attributes each expected character to the finger that types
+// it on the layout of its session, layouts[i] for sessions[i]. Bigrams are taken
+// within a session and never span a space; a same-finger bigram uses one finger
+// for two different keys.
+func ComputeFingerUsage(sessions [][]Keystroke, layouts []keyboard.Layout) FingerUsage {
+	stats := make(map[keyboard.Finger]*FingerStat, len(keyboard.Fingers))
+	for _, f := range keyboard.Fingers {
+		stats[f] = &FingerStat{Finger: f}
+	}
+
+	var usage FingerUsage
+	var mapped, left, right, alternating int
+	for i, keystrokes := range sessions {
+		layout := layouts[i]
+		var prev rune
+		var prevFinger keyboard.Finger
+		havePrev := false
+
+		for _, k := range keystrokes {
+			finger, ok := layout.FingerFor(k.Expected)
+			if !ok {
+				usage.Unmapped++
+				havePrev = false
+				continue
+			}
+
+			stat := stats[finger]
+			stat.Presses++
+			if !k.Correct() {
+				stat.Errors++
+			}
+			mapped++
+
+			if finger == keyboard.Thumb {
+				havePrev = false
+				continue
+			}
+			switch finger.Hand() {
+			case keyboard.LeftHand:
+				left++
+			case keyboard.RightHand:
+				right++
+			}
+
+			if havePrev {
+				usage.Bigrams++
+				if finger == prevFinger && !sameKey(layout, prev, k.Expected) {
+					usage.SameFingerBigrams++
+				}
+				if finger.Hand() != prevFinger.Hand() {
+					alternating++
+				}
+			}
+			prev, prevFinger, havePrev = k.Expected, finger, true
+		}
+	}
+
+	for _, f := range keyboard.Fingers {
+		stat := stats[f]
+		if mapped > 0 {
+			stat.Load = float64(stat.Presses) / float64(mapped)
+		}
+		if stat.Presses > 0 {
+			stat.ErrorRate = float64(stat.Errors) / float64(stat.Presses)
+		}
+		usage.Fingers = append(usage.Fingers, *stat)
+	}
+	if hands := left + right; hands > 0 {
+		usage.LeftHand = float64(left) / float64(hands)
+		usage.RightHand = float64(right) / float64(hands)
+	}
+	if usage.Bigrams > 0 {
+		usage.SameFingerRate = float64(usage.SameFingerBigrams) / float64(usage.Bigrams)
+		usage.AlternationRate = float64(alternating) / float64(usage.Bigrams)
+	}
+	return usage
+}
+
+// sameKey reports whether two characters are typed on the same physical key
+func sameKey(layout keyboard.Layout, a, b rune) bool {
+	ka, okA := layout.KeyFor(a)
+	kb, okB := layout.KeyFor(b)
+	return okA && okB && ka == kb
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Funbox transforms. Mirror and nospace only change how lines are laid out
+// and drawn; the others rewrite the words as they are generated.
+const (
+	FunboxMirror     = "mirror"
+	FunboxReversed   = "reversed"
+	FunboxCaps       = "caps"
+	FunboxRandomCase = "random-case"
+	FunboxNoSpace    = "nospace"
+	FunboxDouble     = "double"
+	FunboxLeet       = "leet"
+)
+
+// Funboxes lists every transform in the order they are applied
+var Funboxes = []string{FunboxDouble, FunboxReversed, FunboxCaps, FunboxRandomCase, FunboxLeet, FunboxNoSpace, FunboxMirror}
+
+// leetLetters maps letters to their leetspeak digits
+var leetLetters = strings.NewReplacer(
+	"a", "4", "A", "4", "e", "3", "E", "3", "i", "1", "I", "1",
+	"o", "0", "O", "0", "s", "5", "S", "5", "t", "7", "T", "7",
+)
+
+// ParseFunbox parses a comma-separated list of transforms, returning them in
+// the order they are applied
+func ParseFunbox(list string) ([]string, error) {
+	var boxes []string
+	for _, name := range strings.Split(strings.ToLower(list), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(Funboxes, name) {
+			return nil, fmt.Errorf("unknown funbox '%s' (available: %s)", name, strings.Join(Funboxes, ", "))
+		}
+		if !slices.Contains(boxes, name) {
+			boxes = append(boxes, name)
+		}
+	}
+	slices.SortFunc(boxes, func(a, b string) int {
+		return slices.Index(Funboxes, a) - slices.Index(Funboxes, b)
+	})
+	return boxes, nil
+}
+
+// FunboxSource wraps a word source so every batch of words passes through the transforms
+func FunboxSource(source func(count int) []string, boxes []string) func(count int) []string {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return func(count int) []string {
+		words := source(count)
+		for _, box := range boxes {
+			words = applyFunbox(box, words, rng)
+		}
+		return words
+	}
+}
+
+// applyFunbox applies a single transform to a batch of words
+func applyFunbox(box string, words []string, rng *rand.Rand) []string {
+	out := make([]string, 0, len(words))
+	if box == FunboxDouble {
+		for _, w := range words {
+			out = append(out, w, w)
+		}
+		return out
+	}
+
+	for _, w := range words {
+		switch box {
+		case FunboxReversed:
+			clusters := Graphemes(w)
+			slices.Reverse(clusters)
+			w = strings.Join(clusters, "")
+		case FunboxCaps:
+			w = strings.ToUpper(w)
+		case FunboxRandomCase:
+			runes := []rune(w)
+			for i, r := range runes {
+				if rng.Intn(2) == 0 {
+					runes[i] = unicode.ToUpper(r)
+				} else {
+					runes[i] = unicode.ToLower(r)
+				}
+			}
+			w = string(runes)
+		case FunboxLeet:
+			w = leetLetters.Replace(w)
+		}
+		out = append(out, w)
+	}
+	return out
+}
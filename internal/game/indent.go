@@ -0,0 +1,88 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndentPolicy controls how leading indentation of code lines is typed
+type IndentPolicy int
+
+const (
+	IndentSkip   IndentPolicy = iota // Jump over indentation after Enter
+	IndentTab                        // Type each tab with the Tab key
+	IndentSpaces                     // Type indentation as spaces; Tab inserts spaces up to the next tab stop
+)
+
+// DefaultTabWidth is the number of spaces a tab stands for
+const DefaultTabWidth = 4
+
+// ParseIndentPolicy converts a policy name (skip, tab, spaces) into an IndentPolicy
+func ParseIndentPolicy(name string) (IndentPolicy, error) {
+	switch strings.ToLower(name) {
+	case "", "skip":
+		return IndentSkip, nil
+	case "tab":
+		return IndentTab, nil
+	case "spaces":
+		return IndentSpaces, nil
+	}
+	return IndentSkip, fmt.Errorf("unknown indent policy '%s' (available: skip, tab, spaces)", name)
+}
+
+// SetIndent changes the indentation policy and tab width, laying out the text again
+func (g *TypingGame) SetIndent(policy IndentPolicy, tabWidth int) {
+	g.Indent = policy
+	if tabWidth > 0 {
+		g.TabWidth = tabWidth
+	}
+	g.generateDisplayLines()
+}
+
+// AddTab handles the Tab key: a literal tab, or spaces up to the next tab stop
+func (g *TypingGame) AddTab() {
+	if g.Indent == IndentTab {
+		g.AddCharacter('\t')
+		return
+	}
+	for n := g.TabWidth - g.CurrentPos%g.TabWidth; n > 0; n-- {
+		g.AddCharacter(' ')
+	}
+}
+
+// layoutLine prepares a command line for display: trailing whitespace is
+// dropped, since it can't be seen, and leading tabs become spaces unless
+// they are typed with Tab
+func (g *TypingGame) layoutLine(line string) string {
+	line = strings.TrimRight(line, " \t")
+	if g.Indent == IndentTab {
+		return line
+	}
+	body := strings.TrimLeft(line, "\t")
+	tabs := len(line) - len(body)
+	return strings.Repeat(" ", tabs*g.TabWidth) + body
+}
+
+// skipIndent moves the cursor past the indentation of the current line
+func (g *TypingGame) skipIndent() {
+	g.lineStart = 0
+	if g.CommandLines && g.Indent == IndentSkip {
+		for g.lineStart < len(g.lineClusters) && isSpace(g.lineClusters[g.lineStart]) {
+			g.lineStart++
+		}
+	}
+	g.CurrentPos = g.lineStart
+}
+
+// HasError reports whether the cluster at index of the current line was typed wrongly
+func (g *TypingGame) HasError(index int) bool {
+	if index < g.lineStart || index >= g.CurrentPos {
+		return false
+	}
+	return g.Errors[g.GlobalPos-(g.CurrentPos-index)]
+}
+
+// isSpace reports whether a cluster is a space or a tab
+func isSpace(cluster string) bool {
+	return cluster == " " || cluster == "\t"
+}
@@ -0,0 +1,79 @@
+package game
+
+import "time"
+
+// KeyStat aggregates the keystrokes recorded for a single expected character
+type KeyStat struct {
+	Presses      int
+	Errors       int
+	TotalLatency time.Duration
+	TimedPresses int
+}
+
+// ErrorRate returns the fraction of presses that were mistyped
+func (s KeyStat) ErrorRate() float64 {
+	if s.Presses == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Presses)
+}
+
+// AvgLatency returns the average time taken to reach this key
+func (s KeyStat) AvgLatency() time.Duration {
+	if s.TimedPresses == 0 {
+		return 0
+	}
+	return s.TotalLatency / time.Duration(s.TimedPresses)
+}
+
+// Add merges another KeyStat into this one
+func (s *KeyStat) Add(other KeyStat) {
+	s.Presses += other.Presses
+	s.Errors += other.Errors
+	s.TotalLatency += other.TotalLatency
+	s.TimedPresses += other.TimedPresses
+}
+
+// maxKeyLatency caps the latency counted per keystroke so pauses don't skew averages
+const maxKeyLatency = 2 * time.Second
+
+// ComputeKeyStats aggregates keystrokes by the character that was expected
+func ComputeKeyStats(keystrokes []Keystroke) map[rune]KeyStat {
+	stats := make(map[rune]KeyStat)
+	for _, k := range keystrokes {
+		stat := stats[k.Expected]
+		stat.Presses++
+		if !k.Correct() {
+			stat.Errors++
+		}
+		if k.Latency > 0 && k.Latency <= maxKeyLatency {
+			stat.TotalLatency += k.Latency
+			stat.TimedPresses++
+		}
+		stats[k.Expected] = stat
+	}
+	return stats
+}
+
+// WPMSeries returns the WPM of correctly typed characters at the end of each
+// second of the session, suitable for charting
+func WPMSeries(keystrokes []Keystroke) []float64 {
+	if len(keystrokes) == 0 {
+		return nil
+	}
+
+	seconds := int(keystrokes[len(keystrokes)-1].Offset/time.Second) + 1
+	series := make([]float64, seconds)
+
+	correct, next := 0, 0
+	for s := 1; s <= seconds; s++ {
+		for next < len(keystrokes) && keystrokes[next].Offset < time.Duration(s)*time.Second {
+			if keystrokes[next].Correct() {
+				correct++
+			}
+			next++
+		}
+		series[s-1] = float64(correct) / 5 / (float64(s) / 60)
+	}
+	return series
+}
@@ -14,13 +14,24 @@ var embeddedLanguages embed.FS
 
 // LanguageData represents the structure of the language JSON files
 type LanguageData struct {
-	Name  string   `json:"name"`
-	Words []string `json:"words"`
+	Name      string   `json:"name"`
+	Direction string   `json:"direction,omitempty"` // "rtl" for right-to-left scripts, LTR otherwise
+	Prompt    string   `json:"prompt,omitempty"`    // Shell prompt shown before command lines
+	Words     []string `json:"words"`
+	Commands  []string `json:"commands,omitempty"`  // Full command lines for shell mode
+	Operators []string `json:"operators,omitempty"` // Operators and symbol combos for symbol drills
 }
 
+// DirectionRTL marks a language written right to left
+const DirectionRTL = "rtl"
+
 // LanguageManager manages loading and caching of language data
 type LanguageManager struct {
 	loadedLanguages    map[string][]string
+	rtlLanguages       map[string]bool
+	commandLines       map[string][]string
+	prompts            map[string]string
+	operators          map[string][]string
 	availableLanguages []string
 }
 
@@ -28,6 +39,10 @@ type LanguageManager struct {
 func NewLanguageManager() *LanguageManager {
 	lm := &LanguageManager{
 		loadedLanguages: make(map[string][]string),
+		rtlLanguages:    make(map[string]bool),
+		commandLines:    make(map[string][]string),
+		prompts:         make(map[string]string),
+		operators:       make(map[string][]string),
 	}
 	if err := lm.scanAvailableLanguages(); err != nil {
 		fmt.Printf("Warning: failed to scan available languages: %v\n", err)
@@ -80,9 +95,43 @@ func (lm *LanguageManager) LoadLanguage(langCode string) ([]string, error) {
 
 	// Cache the loaded language
 	lm.loadedLanguages[langCode] = langData.Words
+	lm.rtlLanguages[langCode] = strings.EqualFold(langData.Direction, DirectionRTL)
+	lm.commandLines[langCode] = langData.Commands
+	lm.prompts[langCode] = langData.Prompt
+	lm.operators[langCode] = langData.Operators
 	return langData.Words, nil
 }
 
+// IsRTL reports whether a loaded language is written right to left
+func (lm *LanguageManager) IsRTL(langCode string) bool {
+	return lm.rtlLanguages[strings.ToLower(langCode)]
+}
+
+// Commands returns the command lines of a loaded language and the prompt shown before them
+func (lm *LanguageManager) Commands(langCode string) ([]string, string) {
+	langCode = strings.ToLower(langCode)
+	return lm.commandLines[langCode], lm.prompts[langCode]
+}
+
+// Operators returns the symbol drill operators of a loaded language
+func (lm *LanguageManager) Operators(langCode string) []string {
+	return lm.operators[strings.ToLower(langCode)]
+}
+
+// ShellLanguages returns the languages that have command lines for shell mode
+func (lm *LanguageManager) ShellLanguages() []string {
+	var shells []string
+	for _, lang := range lm.availableLanguages {
+		if _, err := lm.LoadLanguage(lang); err != nil {
+			continue
+		}
+		if commands, _ := lm.Commands(lang); len(commands) > 0 {
+			shells = append(shells, lang)
+		}
+	}
+	return shells
+}
+
 // GetAvailableLanguages returns a copy of all available language codes
 func (lm *LanguageManager) GetAvailableLanguages() []string {
 	cpy := make([]string, len(lm.availableLanguages))
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Runs of words drawn from one pack before switching, so a mixed session
+// reads like moving between files rather than alternating every word
+const (
+	minRun = 3
+	maxRun = 8
+)
+
+// MixEntry is one language of a session and its share of the words
+type MixEntry struct {
+	Code   string
+	Weight int
+}
+
+// ParseMix parses a language list such as "go", "go,sql,bash" or "go:3,sql:1".
+// Weights default to 1.
+func ParseMix(spec string) ([]MixEntry, error) {
+	var entries []MixEntry
+	for _, part := range strings.Split(strings.ToLower(spec), ",") {
+		code, weight, hasWeight := strings.Cut(strings.TrimSpace(part), ":")
+		if code == "" {
+			return nil, fmt.Errorf("invalid language list '%s' (e.g., go:3,sql:1)", spec)
+		}
+		w := 1
+		if hasWeight {
+			n, err := strconv.Atoi(weight)
+			if err != nil || n < 1 || n > 100 {
+				return nil, fmt.Errorf("weight for '%s' must be a number between 1 and 100", code)
+			}
+			w = n
+		}
+		if !languageManager.IsLanguageAvailable(code) {
+			return nil, fmt.Errorf("language '%s' not available (available: %s)",
+				code, strings.Join(languageManager.GetAvailableLanguages(), ", "))
+		}
+		for _, e := range entries {
+			if e.Code == code {
+				return nil, fmt.Errorf("language '%s' is listed twice", code)
+			}
+		}
+		entries = append(entries, MixEntry{Code: code, Weight: w})
+	}
+	return entries, nil
+}
+
+// FormatMix writes entries back as a language list, leaving out weights of 1
+func FormatMix(entries []MixEntry) string {
+	parts := make([]string, len(entries))
+	for i, e := range entries {
+		parts[i] = e.Code
+		if e.Weight != 1 {
+			parts[i] += ":" + strconv.Itoa(e.Weight)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// wordSource is the word list of one language pack
+type wordSource struct {
+	code       string
+	words      []string
+	weight     int
+	cumulative []int // Cumulative rank weights, nil for uniform selection
+}
+
+// word draws a random word, favoring frequent words when the pack is ranked
+func (s wordSource) word(rng *rand.Rand) string {
+	if len(s.cumulative) == 0 {
+		return s.words[rng.Intn(len(s.words))]
+	}
+	r := rng.Intn(s.cumulative[len(s.cumulative)-1]) + 1 // random in range [1, maxWeight]
+	return s.words[findWordIndex(s.cumulative, r)]
+}
+
+// Generator draws words from one or more language packs by weight
+type Generator struct {
+	sources []wordSource
+	total   int // Sum of the source weights
+	rng     *rand.Rand
+}
+
+// NewGenerator loads the packs of a language mix
+func NewGenerator(entries []MixEntry) (*Generator, error) {
+	g := &Generator{rng: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	for _, e := range entries {
+		words, err := languageManager.LoadLanguage(e.Code)
+		if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 {
+			return nil, fmt.Errorf("language '%s' has no words", e.Code)
+		}
+		s := wordSource{code: e.Code, words: words, weight: e.Weight}
+		// Only English is ranked by frequency
+		if e.Code == "en" {
+			s.cumulative = rankWeights(words)
+		}
+		g.sources = append(g.sources, s)
+		g.total += e.Weight
+	}
+	if len(g.sources) == 0 {
+		return nil, fmt.Errorf("no languages given")
+	}
+	return g, nil
+}
+
+// Words returns count words, switching packs every few words in proportion to their weights
+func (g *Generator) Words(count int) []string {
+	words := make([]string, 0, count)
+	for len(words) < count {
+		s := g.pick()
+		run := count
+		if len(g.sources) > 1 {
+			run = minRun + g.rng.Intn(maxRun-minRun+1)
+		}
+		for i := 0; i < run && len(words) < count; i++ {
+			words = append(words, s.word(g.rng))
+		}
+	}
+	return words
+}
+
+// pick chooses a source with probability proportional to its weight
+func (g *Generator) pick() wordSource {
+	r := g.rng.Intn(g.total)
+	for _, s := range g.sources {
+		if r < s.weight {
+			return s
+		}
+		r -= s.weight
+	}
+	return g.sources[len(g.sources)-1]
+}
+
+// allWords returns the words of every pack in the mix
+func (g *Generator) allWords() []string {
+	var words []string
+	for _, s := range g.sources {
+		words = append(words, s.words...)
+	}
+	return words
+}
+
+// rankWeights returns cumulative weights inversely proportional to each word's rank
+func rankWeights(words []string) []int {
+	cumulative := make([]int, len(words))
+	cumSum := 0
+	for i := range words {
+		cumSum += len(words) - i
+		cumulative[i] = cumSum
+	}
+	return cumulative
+}
+
+// findWordIndex uses binary search to find the index of the word based on the random number r
+func findWordIndex(cumulative []int, r int) int {
+	return sort.Search(len(cumulative), func(i int) bool {
+		return cumulative[i] >= r
+	})
+}
@@ -0,0 +1,52 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// DefaultPrompt is shown before command lines when a language doesn't set its own
+const DefaultPrompt = "$ "
+
+// ShellSource returns a source of whole command lines in the current language
+// for shell mode. Each "word" it supplies is a full command, typed on a line of
+// its own and completed with Enter. Multi-line commands such as loops are
+// supplied whole, keeping their newlines and indentation, and the game lays
+// them out a line at a time.
+func ShellSource() (func(count int) []string, error) {
+	if IsMixed() {
+		return nil, fmt.Errorf("shell mode takes a single language, not '%s'", currentLanguageCode)
+	}
+	code := currentMix[0].Code
+	commands, _ := languageManager.Commands(code)
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("language '%s' has no command lines for shell mode (available: %s)",
+			code, strings.Join(languageManager.ShellLanguages(), ", "))
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	last := -1
+	return func(count int) []string {
+		var lines []string
+		for len(lines) < count {
+			n := rng.Intn(len(commands))
+			// Avoid the same command twice in a row
+			if n == last && len(commands) > 1 {
+				n = (n + 1 + rng.Intn(len(commands)-1)) % len(commands)
+			}
+			last = n
+			lines = append(lines, commands[n])
+		}
+		return lines
+	}, nil
+}
+
+// ShellPrompt returns the prompt shown before command lines of the current language
+func ShellPrompt() string {
+	if _, prompt := languageManager.Commands(currentMix[0].Code); prompt != "" {
+		return prompt
+	}
+	return DefaultPrompt
+}
@@ -0,0 +1,121 @@
+package game
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SymbolChars are the characters symbol drills concentrate on
+const SymbolChars = "{}[]()<>;:=!&|"
+
+// commonCombos stand in for the operators of a language pack that lists none
+var commonCombos = []string{
+	":=", "=>", "->", "!=", "!==", "==", "===", "&&", "||", "<<", ">>", "<<=", ">>=",
+	"<=", ">=", "::", "+=", "-=", "|=", "&=", "()", "[]", "{}", "();", "{};", "[];", "!(",
+}
+
+// openBrackets are the brackets operands get wrapped in
+const openBrackets = "([{<"
+
+// bracketPairs maps opening brackets to their closing counterpart
+var bracketPairs = map[rune]rune{'(': ')', '[': ']', '{': '}', '<': '>'}
+
+// SymbolSource returns a word source for symbol drills in the current language.
+// Operators come from the "operators" list of each language pack, or common
+// combos such as := and => for packs without one, along with symbol tokens
+// among the pack's words. They are combined with the pack's short keywords as
+// operands.
+func SymbolSource() func(count int) []string {
+	combos, symbolic, identifiers := symbolTokens(currentLanguageWords)
+	for _, e := range currentMix {
+		packCombos := languageManager.Operators(e.Code)
+		if len(packCombos) == 0 {
+			packCombos = commonCombos
+		}
+		for _, c := range packCombos {
+			if !slices.Contains(combos, c) {
+				combos = append(combos, c)
+			}
+		}
+	}
+
+	// Only pure symbol operators join two operands, as in a&&b
+	var operators []string
+	for _, c := range combos {
+		if !strings.ContainsAny(c, "()[]{};\"'`") && !strings.ContainsFunc(c, isWordRune) {
+			operators = append(operators, c)
+		}
+	}
+	if len(operators) == 0 {
+		operators = []string{"==", "&&", "||"}
+	}
+	if len(identifiers) == 0 {
+		identifiers = []string{"a", "b", "i", "x", "ok", "err", "val"}
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	pick := func(list []string) string { return list[rng.Intn(len(list))] }
+
+	return func(count int) []string {
+		words := make([]string, count)
+		for i := range words {
+			switch n := rng.Intn(10); {
+			case n < 3:
+				// A bare operator
+				words[i] = pick(combos)
+			case n < 5 && len(symbolic) > 0:
+				// A symbol-heavy token straight from the pack, like Some(T) or &mut
+				words[i] = pick(symbolic)
+			case n < 7:
+				// An operand wrapped in brackets, like (err) or [i];
+				open := []rune(openBrackets)[rng.Intn(len(openBrackets))]
+				words[i] = string(open) + pick(identifiers) + string(bracketPairs[open])
+				if rng.Intn(3) == 0 {
+					words[i] += ";"
+				}
+			default:
+				// Two operands joined by an operator, like a&&b
+				words[i] = pick(identifiers) + pick(operators) + pick(identifiers)
+			}
+		}
+		return words
+	}
+}
+
+// isWordRune reports whether r is a letter or digit
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// symbolTokens splits a pack's words into operator tokens (no letters or digits),
+// symbol-heavy words and short alphabetic identifiers usable as operands
+func symbolTokens(words []string) (combos, symbolic, identifiers []string) {
+	for _, w := range words {
+		if strings.ContainsRune(w, ' ') {
+			continue
+		}
+		letters, symbols := 0, 0
+		for _, r := range w {
+			switch {
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				letters++
+			case strings.ContainsRune(SymbolChars, r) || unicode.IsPunct(r) || unicode.IsSymbol(r):
+				symbols++
+			}
+		}
+		switch {
+		case letters == 0 && symbols > 0:
+			if len(w) > 1 && !slices.Contains(combos, w) {
+				combos = append(combos, w)
+			}
+		case symbols > 0 && strings.ContainsAny(w, SymbolChars):
+			symbolic = append(symbolic, w)
+		case symbols == 0 && len(w) <= 6:
+			identifiers = append(identifiers, w)
+		}
+	}
+	return combos, symbolic, identifiers
+}
@@ -1,8 +1,11 @@
 package game
 
 import (
+	"fmt"
 	"strings"
 	"time"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // TypingStats holds the statistics for a game session
@@ -17,6 +20,41 @@ type TypingStats struct {
 	UncorrectedErrors int
 }
 
+// Keystroke records a single character entered during a game session
+type Keystroke struct {
+	Expected rune          `json:"expected"`
+	Typed    rune          `json:"typed"`
+	Latency  time.Duration `json:"latency"` // Time since the previous keystroke, zero for the first one
+	Offset   time.Duration `json:"offset"`  // Time since the start of the session
+}
+
+// Correct reports whether the typed character matched the expected one
+func (k Keystroke) Correct() bool {
+	return k.Expected == k.Typed
+}
+
+// BackspacePolicy controls how far back typed characters may be deleted
+type BackspacePolicy int
+
+const (
+	BackspaceAllow BackspacePolicy = iota // Delete freely within the current line
+	BackspaceWord                         // Delete only within the current word
+	BackspaceOff                          // Never delete
+)
+
+// ParseBackspacePolicy converts a policy name (allow, word, off) into a BackspacePolicy
+func ParseBackspacePolicy(name string) (BackspacePolicy, error) {
+	switch strings.ToLower(name) {
+	case "", "allow":
+		return BackspaceAllow, nil
+	case "word":
+		return BackspaceWord, nil
+	case "off":
+		return BackspaceOff, nil
+	}
+	return BackspaceAllow, fmt.Errorf("unknown backspace policy '%s' (available: allow, word, off)", name)
+}
+
 // TypingGame represents the state of a game session
 type TypingGame struct {
 	AllWords        []string
@@ -33,56 +71,125 @@ type TypingGame struct {
 	LinesPerView    int
 	CharsPerLine    int
 	WordsTyped      int
+	Keystrokes      []Keystroke
+	Backspace       BackspacePolicy
+	FoldASCII       bool                     // Accept unaccented letters for accented ones, e.g. e for é
+	WordSource      func(count int) []string // Supplies more words as the text runs out, GenerateWords if nil
+	CommandLines    bool                     // Each word is a whole command on its own line, ended with Enter
+	Indent          IndentPolicy             // How leading indentation of command lines is typed
+	TabWidth        int                      // Spaces per tab when indentation is laid out as spaces
+	Difficulty      Difficulty               // How strictly mistakes are punished
+	FailReason      string                   // Why the test ended early, empty unless it failed
+	Misses          int                      // Wrong keys that didn't advance, in stop-on-error or at a line end
+	LinesShiftedAt  time.Time                // When the text last moved up a line
+	NoSpace         bool                     // Words are run together and lines end with their last character
+	lastKeystroke   time.Time
+	lineStart       int      // Position on the current line where typing starts, past skipped indentation
+	lineWords       int      // Number of words laid out on the current line
+	commandLine     int      // Line of the command at WordsTyped shown first, in shell mode
+	continued       []bool   // Whether each display line continues the command above it
+	lineClusters    []string // Grapheme clusters of the current line
+	pending         []rune   // Runes of a cluster still waiting for combining marks
+	typedLens       []int    // Byte length of each cluster appended to UserInput
 }
 
 // NewTypingGame initializes a new TypingGame instance with a specified duration
 func NewTypingGame(duration int) *TypingGame {
+	return NewTypingGameFromWords(duration, GenerateWords(200))
+}
+
+// NewTypingGameFromWords initializes a new TypingGame that types the given words first
+func NewTypingGameFromWords(duration int, words []string) *TypingGame {
 	game := &TypingGame{
-		AllWords:     GenerateWords(200),
+		AllWords:     words,
 		Duration:     duration,
 		Errors:       make(map[int]bool),
 		LinesPerView: 3,
 		CharsPerLine: 50,
+		TabWidth:     DefaultTabWidth,
 	}
 	game.generateDisplayLines()
 	return game
 }
 
-// Reset reinitializes the game to a fresh state
+// Reset reinitializes the game to a fresh state, keeping its settings
 func (g *TypingGame) Reset() {
-	*g = *NewTypingGame(g.Duration)
+	backspace, fold, source, commands := g.Backspace, g.FoldASCII, g.WordSource, g.CommandLines
+	indent, tabWidth, difficulty, noSpace := g.Indent, g.TabWidth, g.Difficulty, g.NoSpace
+	*g = *NewTypingGameFromWords(g.Duration, g.generate(200))
+	g.Backspace = backspace
+	g.FoldASCII = fold
+	g.WordSource = source
+	g.Indent, g.TabWidth = indent, tabWidth
+	g.Difficulty = difficulty
+	g.NoSpace = noSpace
+	g.SetCommandLines(commands)
 }
 
 // generateDisplayLines creates the initial display lines based on the words available
 func (g *TypingGame) generateDisplayLines() {
+	g.lineWords = 0
+	var lines []string
+	if g.CommandLines {
+		lines = g.commandDisplayLines()
+	} else {
+		lines = g.wordDisplayLines()
+	}
+
+	// Ensure we have exactly g.LinesPerView lines
+	for len(lines) < g.LinesPerView {
+		lines = append(lines, "")
+	}
+
+	// Truncate if somehow we have more than g.LinesPerView lines
+	if len(lines) > g.LinesPerView {
+		lines = lines[:g.LinesPerView]
+	}
+
+	g.DisplayLines = lines
+	g.lineClusters = Graphemes(lines[0])
+	g.skipIndent()
+}
+
+// wordDisplayLines fills the display lines with as many words from WordsTyped
+// on as fit on each
+func (g *TypingGame) wordDisplayLines() []string {
 	lines := make([]string, 0, g.LinesPerView)
 	wordIndex := g.WordsTyped
 
+	// Words are separated by a space unless they run together
+	separator := " "
+	if g.NoSpace {
+		separator = ""
+	}
+
 	// Generate exactly g.LinesPerView lines
 	for lineNum := 0; lineNum < g.LinesPerView && wordIndex < len(g.AllWords); lineNum++ {
 		var currentLine strings.Builder
+		lineWidth := 0
 
-		// Fill current line with words
+		// Fill current line with words, measured in terminal cells
 		for wordIndex < len(g.AllWords) {
 			word := g.AllWords[wordIndex]
+			wordWidth := StringWidth(word)
 			spaceNeeded := 0
-			if currentLine.Len() > 0 {
-				spaceNeeded = 1
+			if lineWidth > 0 {
+				spaceNeeded = len(separator)
 			}
 
 			// Check if word fits
-			if currentLine.Len()+spaceNeeded+len(word) <= g.CharsPerLine {
-				if currentLine.Len() > 0 {
-					currentLine.WriteString(" ")
+			if lineWidth+spaceNeeded+wordWidth <= g.CharsPerLine {
+				if lineWidth > 0 {
+					currentLine.WriteString(separator)
 				}
 				currentLine.WriteString(word)
+				lineWidth += spaceNeeded + wordWidth
 				wordIndex++
+				if lineNum == 0 {
+					g.lineWords++
+				}
 			} else {
 				// Word doesn't fit, break to next line
-				// Remove any trailing spaces
-				lineStr := strings.TrimRight(currentLine.String(), " ")
-				currentLine.Reset()
-				currentLine.WriteString(lineStr)
 				break
 			}
 		} // Add the completed line
@@ -93,18 +200,36 @@ func (g *TypingGame) generateDisplayLines() {
 			lines = append(lines, "")
 		}
 	}
+	return lines
+}
 
-	// Ensure we have exactly g.LinesPerView lines
-	for len(lines) < g.LinesPerView {
-		lines = append(lines, "")
-	}
-
-	// Truncate if somehow we have more than g.LinesPerView lines
-	if len(lines) > g.LinesPerView {
-		lines = lines[:g.LinesPerView]
+// commandDisplayLines lays out each line of the commands from WordsTyped on its
+// own display line, starting at the line of the command being typed. The
+// current line holds a word only when it finishes its command, so a
+// multi-line command counts once.
+func (g *TypingGame) commandDisplayLines() []string {
+	var lines []string
+	g.continued = g.continued[:0]
+	wordIndex, part := g.WordsTyped, g.commandLine
+	for len(lines) < g.LinesPerView && wordIndex < len(g.AllWords) {
+		parts := strings.Split(g.AllWords[wordIndex], "\n")
+		lines = append(lines, g.layoutLine(parts[part]))
+		g.continued = append(g.continued, part > 0)
+		part++
+		if part == len(parts) {
+			if len(lines) == 1 {
+				g.lineWords = 1
+			}
+			wordIndex, part = wordIndex+1, 0
+		}
 	}
+	return lines
+}
 
-	g.DisplayLines = lines
+// ContinuesCommand reports whether display line i continues a command begun
+// on an earlier line, in shell mode
+func (g *TypingGame) ContinuesCommand(i int) bool {
+	return i < len(g.continued) && g.continued[i]
 }
 
 // Start initializes the game session if it hasn't started yet
@@ -115,7 +240,8 @@ func (g *TypingGame) Start() {
 	}
 }
 
-// AddCharacter handles user input and updates game state
+// AddCharacter handles user input and updates game state. Combining marks
+// typed after a base character are merged into the same grapheme cluster.
 func (g *TypingGame) AddCharacter(char rune) {
 	if !g.IsStarted {
 		g.Start()
@@ -126,51 +252,195 @@ func (g *TypingGame) AddCharacter(char rune) {
 		return
 	}
 
-	lineText := []rune(g.DisplayLines[0])
+	// A combining mark can only extend a pending cluster; anything else settles it
+	if IsCombining(char) && len(g.pending) == 0 {
+		return
+	}
+	if len(g.pending) > 0 && !IsCombining(char) {
+		g.commitPending()
+	}
 
-	// If at end of line, only shift if user just typed space
-	if g.CurrentPos == len(lineText) {
-		if char == ' ' {
-			g.UserInput += string(char)
-			g.CurrentPos++
-			g.GlobalPos++
-			g.shiftLines()
+	// If at end of line, only shift if user just typed space (Enter for command lines)
+	if g.CurrentPos == len(g.lineClusters) {
+		want := g.LineEnd()
+		if char != want {
+			// Other keys are ignored, except where a wrong key is punished.
+			// With no cluster left to mark, it counts as a miss.
+			if g.Difficulty == DifficultyMaster || g.Difficulty == DifficultyStop {
+				g.recordKeystroke(want, char)
+				g.Misses++
+			}
+			if g.Difficulty == DifficultyMaster {
+				g.fail(fmt.Sprintf("typed %s instead of %s", describeKey(char), describeKey(want)))
+			}
+			return
 		}
+		g.recordKeystroke(char, char)
+		if word, bad := g.submittedWord(); bad && g.Difficulty == DifficultyExpert {
+			g.fail(fmt.Sprintf("submitted \"%s\" with a mistake", word))
+			return
+		}
+		g.UserInput += string(char)
+		g.typedLens = append(g.typedLens, 1)
+		g.CurrentPos++
+		g.GlobalPos++
+		g.shiftLines()
 		return
 	}
 
 	// Normal character processing
-	if g.CurrentPos < len(lineText) && g.CurrentPos >= 0 {
-		g.UserInput += string(char)
-		if lineText[g.CurrentPos] != char {
-			g.Errors[g.GlobalPos] = true
-			g.TotalErrorsMade++
+	if g.CurrentPos < len(g.lineClusters) && g.CurrentPos >= 0 {
+		g.pending = append(g.pending, char)
+		expected := norm.NFD.String(g.lineClusters[g.CurrentPos])
+		typed := norm.NFD.String(string(g.pending))
+
+		// Wait for the rest of a cluster typed as a base plus combining marks
+		if !g.matches(expected, typed) && strings.HasPrefix(expected, typed) {
+			return
 		}
-		g.CurrentPos++
-		g.GlobalPos++
+		g.commitPending()
+	}
+}
+
+// commitPending scores the pending runes against the current cluster and advances
+func (g *TypingGame) commitPending() {
+	typed := norm.NFC.String(string(g.pending))
+	g.pending = nil
+	expected := g.lineClusters[g.CurrentPos]
+
+	// A folded match is logged as the expected character so it counts as correct
+	want, got := clusterRune(expected), clusterRune(typed)
+	correct := g.matches(norm.NFD.String(expected), norm.NFD.String(typed))
+	if correct {
+		got = want
+	}
+	g.recordKeystroke(want, got)
+
+	// In stop-on-error the cursor stays put until the right key is pressed
+	if !correct && g.Difficulty == DifficultyStop {
+		g.Misses++
+		return
+	}
+
+	g.UserInput += typed
+	g.typedLens = append(g.typedLens, len(typed))
+	if !correct {
+		g.Errors[g.GlobalPos] = true
+		g.TotalErrorsMade++
+	}
+	g.CurrentPos++
+	g.GlobalPos++
+
+	switch {
+	case !correct && g.Difficulty == DifficultyMaster:
+		g.fail(fmt.Sprintf("typed %s instead of %s", describeKey(got), describeKey(want)))
+	case typed == " " && g.Difficulty == DifficultyExpert:
+		if word, bad := g.submittedWord(); bad {
+			g.fail(fmt.Sprintf("submitted \"%s\" with a mistake", word))
+		}
+	}
+
+	// Without spaces there is nothing to type at the end of a line, so
+	// finishing it submits it
+	if g.NoSpace && !g.IsFinished && g.CurrentPos == len(g.lineClusters) {
+		if word, bad := g.submittedWord(); bad && g.Difficulty == DifficultyExpert {
+			g.fail(fmt.Sprintf("submitted \"%s\" with a mistake", word))
+			return
+		}
+		g.shiftLines()
+	}
+}
+
+// matches reports whether the typed cluster is accepted for the expected one
+func (g *TypingGame) matches(expected, typed string) bool {
+	if expected == typed {
+		return true
+	}
+	return g.FoldASCII && FoldASCII(expected) == FoldASCII(typed)
+}
+
+// recordKeystroke appends a keystroke to the session log with its latency
+func (g *TypingGame) recordKeystroke(expected, typed rune) {
+	now := time.Now()
+	var latency time.Duration
+	if !g.lastKeystroke.IsZero() {
+		latency = now.Sub(g.lastKeystroke)
 	}
+	g.lastKeystroke = now
+	g.Keystrokes = append(g.Keystrokes, Keystroke{
+		Expected: expected,
+		Typed:    typed,
+		Latency:  latency,
+		Offset:   now.Sub(g.StartTime),
+	})
 }
 
 // shiftLines moves to the next line in the game, updating the words typed and generating new lines
 func (g *TypingGame) shiftLines() {
-	// Move to next line
-	g.WordsTyped += len(strings.Fields(g.DisplayLines[0]))
-	g.CurrentPos = 0
+	// Move to next line, or to the next line of a multi-line command
+	if g.CommandLines && g.lineWords == 0 {
+		g.commandLine++
+	} else {
+		g.WordsTyped += g.lineWords
+		g.commandLine = 0
+	}
 
 	// Generate new lines
 	g.generateDisplayLines()
+	g.LinesShiftedAt = time.Now()
 
 	// Extend words if needed
 	if g.WordsTyped > len(g.AllWords)-50 {
-		newWords := GenerateWords(100)
+		newWords := g.generate(100)
 		g.AllWords = append(g.AllWords, newWords...)
 	}
 }
 
+// SetCommandLines switches between flowing words and one command per line,
+// laying out the text again
+func (g *TypingGame) SetCommandLines(on bool) {
+	g.CommandLines = on
+	g.generateDisplayLines()
+}
+
+// SetNoSpace switches between words separated by spaces and words run
+// together, laying out the text again
+func (g *TypingGame) SetNoSpace(on bool) {
+	g.NoSpace = on
+	g.generateDisplayLines()
+}
+
+// LineEnd returns the character that finishes a display line: a space, or a
+// newline typed with Enter when every line is a command
+func (g *TypingGame) LineEnd() rune {
+	if g.CommandLines {
+		return '\n'
+	}
+	return ' '
+}
+
+// generate returns count more words from the game's word source
+func (g *TypingGame) generate(count int) []string {
+	if g.WordSource != nil {
+		return g.WordSource(count)
+	}
+	return GenerateWords(count)
+}
+
 // RemoveCharacter removes the last character from the user input and updates the position
 func (g *TypingGame) RemoveCharacter() {
-	if len(g.UserInput) > 0 && g.CurrentPos > 0 {
-		g.UserInput = g.UserInput[:len(g.UserInput)-1]
+	// An unfinished cluster is discarded first
+	if len(g.pending) > 0 {
+		g.pending = nil
+		return
+	}
+	if !g.CanRemoveCharacter() {
+		return
+	}
+	if len(g.typedLens) > 0 && g.CurrentPos > g.lineStart {
+		last := g.typedLens[len(g.typedLens)-1]
+		g.typedLens = g.typedLens[:len(g.typedLens)-1]
+		g.UserInput = g.UserInput[:len(g.UserInput)-last]
 		g.CurrentPos--
 		g.GlobalPos--
 
@@ -179,6 +449,20 @@ func (g *TypingGame) RemoveCharacter() {
 	}
 }
 
+// CanRemoveCharacter reports whether the backspace policy allows deleting the last character
+func (g *TypingGame) CanRemoveCharacter() bool {
+	switch g.Backspace {
+	case BackspaceOff:
+		return false
+	case BackspaceWord:
+		// Don't allow stepping back over the space that ended the previous word
+		if g.CurrentPos > 0 && g.CurrentPos <= len(g.lineClusters) && g.lineClusters[g.CurrentPos-1] == " " {
+			return false
+		}
+	}
+	return true
+}
+
 // GetDisplayText returns the current text to be displayed in the game
 func (g *TypingGame) GetDisplayText() string {
 	return strings.Join(g.DisplayLines, "")
@@ -211,11 +495,13 @@ func (g *TypingGame) GetStats() TypingStats {
 		netWPM = 0
 	}
 
-	// Calculate accuracy (correct characters / total characters typed * 100)
+	// Calculate accuracy (correct characters / total characters typed * 100),
+	// counting keys that were rejected without advancing as typed too
 	correctChars := g.GlobalPos - g.TotalErrorsMade
+	attempts := g.GlobalPos + g.Misses
 	accuracy := 0.0
-	if g.GlobalPos > 0 {
-		accuracy = float64(correctChars) / float64(g.GlobalPos) * 100
+	if attempts > 0 {
+		accuracy = float64(correctChars) / float64(attempts) * 100
 	}
 
 	// Ensure accuracy doesn't go below 0
@@ -228,7 +514,7 @@ func (g *TypingGame) GetStats() TypingStats {
 		Accuracy:          accuracy,
 		CharactersTyped:   g.GlobalPos,
 		CorrectChars:      correctChars,
-		TotalChars:        len([]rune(g.GetDisplayText())),
+		TotalChars:        len(Graphemes(g.GetDisplayText())),
 		TimeElapsed:       elapsed,
 		IsComplete:        g.IsFinished,
 		UncorrectedErrors: uncorrectedErrors,
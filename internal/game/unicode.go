@@ -0,0 +1,63 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Graphemes splits s into user-perceived characters (grapheme clusters)
+func Graphemes(s string) []string {
+	var clusters []string
+	g := uniseg.NewGraphemes(s)
+	for g.Next() {
+		clusters = append(clusters, g.Str())
+	}
+	return clusters
+}
+
+// StringWidth returns the number of terminal cells s occupies
+func StringWidth(s string) int {
+	return uniseg.StringWidth(s)
+}
+
+// IsCombining reports whether r is a combining mark that attaches to the previous character
+func IsCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc)
+}
+
+// foldSpecial maps letters that don't decompose into an ASCII base plus marks
+var foldSpecial = map[rune]rune{
+	'ø': 'o', 'Ø': 'O',
+	'ł': 'l', 'Ł': 'L',
+	'đ': 'd', 'Đ': 'D',
+	'ı': 'i',
+	'‘': '\'', '’': '\'',
+	'“': '"', '”': '"',
+	'–': '-', '—': '-',
+}
+
+// FoldASCII strips diacritics from s so that e.g. "é" becomes "e"
+func FoldASCII(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(s) {
+		if IsCombining(r) {
+			continue
+		}
+		if f, ok := foldSpecial[r]; ok {
+			r = f
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// clusterRune returns the rune recorded in the keystroke log for a cluster,
+// its composed form when that is a single rune
+func clusterRune(cluster string) rune {
+	r, _ := utf8.DecodeRuneInString(norm.NFC.String(cluster))
+	return r
+}
@@ -1,17 +1,15 @@
 package game
 
 import (
-	"math/rand"
-	"sort"
 	"strings"
-	"time"
 )
 
 var languageManager *LanguageManager
-var currentLanguageWords []string
-var weights []int
-var cumulativeWeights []int
+var currentGenerator *Generator
+var currentLanguageWords []string // Words of every pack in the session, for drills built from them
 var currentLanguageCode string
+var currentLanguageRTL bool
+var currentMix []MixEntry
 
 // init initializes the language manager and sets the default language to "en"
 func init() {
@@ -22,92 +20,54 @@ func init() {
 	}
 }
 
-// SetLanguage sets the current language for the game and loads the corresponding words
+// SetLanguage sets the current language for the game and loads the corresponding
+// words. A list such as "go:3,sql:1" mixes several packs in one session.
 func SetLanguage(langCode string) error {
-	words, err := languageManager.LoadLanguage(langCode)
+	entries, err := ParseMix(langCode)
 	if err != nil {
 		return err
 	}
-
-	currentLanguageWords = words
-	currentLanguageCode = langCode
-
-	// Only calculate weights for English
-	if langCode == "en" {
-		calculateWeights()
-	} else {
-		weights = nil
-		cumulativeWeights = nil
-	}
-
-	return nil
-}
-
-// calculateWeights calculates the weights for the words based on their rank
-func calculateWeights() {
-	if len(currentLanguageWords) == 0 {
-		return
-	}
-
-	// Initialize weights inversely proportional to rank
-	weights = make([]int, len(currentLanguageWords))
-	for i := range currentLanguageWords {
-		weights[i] = len(currentLanguageWords) - i
+	gen, err := NewGenerator(entries)
+	if err != nil {
+		return err
 	}
 
-	// Calculate cumulative weights for binary search
-	cumulativeWeights = make([]int, len(weights))
-	cumSum := 0
-	for i, w := range weights {
-		cumSum += w
-		cumulativeWeights[i] = cumSum
-	}
-}
+	currentGenerator = gen
+	currentLanguageWords = gen.allWords()
+	currentLanguageCode = FormatMix(entries)
+	currentMix = entries
 
-// findWordIndex uses binary search to find the index of the word based on the random number r
-func findWordIndex(r int) int {
-	if len(cumulativeWeights) == 0 {
-		return 0
+	// A mix is only laid out right to left if every pack in it is
+	currentLanguageRTL = true
+	for _, e := range entries {
+		currentLanguageRTL = currentLanguageRTL && languageManager.IsRTL(e.Code)
 	}
 
-	return sort.Search(len(cumulativeWeights), func(i int) bool {
-		return cumulativeWeights[i] >= r
-	})
+	return nil
 }
 
 // GenerateWords generates a slice of words based on the current language and the specified count
 func GenerateWords(count int) []string {
-	if len(currentLanguageWords) == 0 {
+	if currentGenerator == nil {
 		// Fallback to English
 		if err := SetLanguage("en"); err != nil {
 			panic("failed to load fallback language: " + err.Error())
 		}
 	}
-
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	words := make([]string, count)
-
-	// Use weighted selection only for English
-	if currentLanguageCode == "en" && len(cumulativeWeights) > 0 {
-		maxWeight := cumulativeWeights[len(cumulativeWeights)-1]
-
-		for i := range words {
-			r := rng.Intn(maxWeight) + 1 // random in range [1, maxWeight]
-			idx := findWordIndex(r)
-			words[i] = currentLanguageWords[idx]
-		}
-		return words
-	}
-
-	// For all other languages, use simple random selection
-	for i := range words {
-		words[i] = currentLanguageWords[rng.Intn(len(currentLanguageWords))]
-	}
-
-	return words
+	return currentGenerator.Words(count)
 }
 
 // GenerateText generates a string of words joined by spaces
 func GenerateText(words []string) string {
 	return strings.Join(words, " ")
 }
+
+// IsRTL reports whether the current language is written right to left
+func IsRTL() bool {
+	return currentLanguageRTL
+}
+
+// IsMixed reports whether the session draws words from more than one pack
+func IsMixed() bool {
+	return len(currentMix) > 1
+}
@@ -0,0 +1,136 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExportFormats lists the supported export formats
+var ExportFormats = []string{"csv", "json", "md"}
+
+// exportRow is the flattened, keystroke-free view of a record used by exports
+type exportRow struct {
+	ID         string  `json:"id"`
+	Timestamp  string  `json:"timestamp"`
+	Language   string  `json:"language"`
+	Mode       string  `json:"mode"`
+	Layout     string  `json:"layout"`
+	Duration   int     `json:"duration"`
+	WPM        float64 `json:"wpm"`
+	Accuracy   float64 `json:"accuracy"`
+	Difficulty string  `json:"difficulty"`
+	Visibility string  `json:"visibility"`
+	Funbox     string  `json:"funbox"`  // Transforms joined with ;
+	Failure    string  `json:"failure"` // Empty unless the test failed early
+	Tags       string  `json:"tags"`
+}
+
+// exportHeader is the column order shared by the CSV and Markdown exports
+var exportHeader = []string{"id", "timestamp", "language", "mode", "layout", "duration", "wpm", "accuracy", "difficulty", "visibility", "funbox", "failure", "tags"}
+
+// fields returns the row values in exportHeader order
+func (r exportRow) fields() []string {
+	return []string{
+		r.ID,
+		r.Timestamp,
+		r.Language,
+		r.Mode,
+		r.Layout,
+		fmt.Sprintf("%d", r.Duration),
+		fmt.Sprintf("%.2f", r.WPM),
+		fmt.Sprintf("%.2f", r.Accuracy),
+		r.Difficulty,
+		r.Visibility,
+		r.Funbox,
+		r.Failure,
+		r.Tags,
+	}
+}
+
+// FilterSince returns the records taken at or after since
+func FilterSince(records []Record, since time.Time) []Record {
+	var filtered []Record
+	for _, r := range records {
+		if !r.Timestamp.Before(since) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// Export writes records to w in the given format (csv, json or md)
+func Export(w io.Writer, records []Record, format string) error {
+	rows := make([]exportRow, len(records))
+	for i, r := range records {
+		rows[i] = exportRow{
+			ID:         r.ID,
+			Timestamp:  r.Timestamp.Format(time.RFC3339),
+			Language:   r.Language,
+			Mode:       r.Mode,
+			Layout:     r.Layout,
+			Duration:   r.Duration,
+			WPM:        r.WPM,
+			Accuracy:   r.Accuracy,
+			Difficulty: orNormal(r.Difficulty),
+			Visibility: orNormal(r.Visibility),
+			Funbox:     strings.Join(r.Funbox, ";"),
+			Failure:    r.Failure,
+			Tags:       strings.Join(r.Tags, ";"),
+		}
+	}
+
+	switch strings.ToLower(format) {
+	case "csv":
+		return exportCSV(w, rows)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case "md":
+		return exportMarkdown(w, rows)
+	}
+	return fmt.Errorf("unknown export format '%s' (available: %s)", format, strings.Join(ExportFormats, ", "))
+}
+
+// orNormal names the setting a record leaves empty for the default, normal
+func orNormal(value string) string {
+	if value == "" {
+		return "normal"
+	}
+	return value
+}
+
+// exportCSV writes rows as comma-separated values with a header line
+func exportCSV(w io.Writer, rows []exportRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportHeader); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := cw.Write(row.fields()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// exportMarkdown writes rows as a GitHub-flavored Markdown table
+func exportMarkdown(w io.Writer, rows []exportRow) error {
+	var b strings.Builder
+	b.WriteString("| " + strings.Join(exportHeader, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(exportHeader)) + "\n")
+	for _, row := range rows {
+		fields := row.fields()
+		for i, f := range fields {
+			fields[i] = strings.ReplaceAll(f, "|", "\\|")
+		}
+		b.WriteString("| " + strings.Join(fields, " | ") + " |\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,203 @@
+package history
+
+import (
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tags attached to imported records
+const (
+	TagImported        = "imported"
+	TagUnknownLanguage = "unknown-language"
+	TagUnknownMode     = "unknown-mode"
+)
+
+// Placeholders stored when an imported language or mode has no typtea equivalent
+const (
+	LanguageOther = "other"
+	ModeOther     = "other"
+)
+
+// importColumns maps the column names used by popular typing tests onto our fields
+var importColumns = map[string][]string{
+	"id":        {"_id", "id"},
+	"wpm":       {"wpm", "net_wpm", "netwpm"},
+	"accuracy":  {"acc", "accuracy"},
+	"mode":      {"mode", "test_mode"},
+	"mode2":     {"mode2"},
+	"duration":  {"testduration", "duration", "time"},
+	"language":  {"language", "lang"},
+	"timestamp": {"timestamp", "date", "datetime", "created_at"},
+}
+
+// timestampLayouts are the textual timestamp formats accepted on import
+var timestampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	time.DateOnly,
+}
+
+// LanguageResolver maps a foreign language name to a typtea language code
+type LanguageResolver func(name string) (string, bool)
+
+// ImportCSV parses a CSV export from another typing tool into records.
+// Languages and modes without a typtea equivalent are kept as tags.
+func ImportCSV(r io.Reader, source string, resolve LanguageResolver) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV header: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for field, aliases := range importColumns {
+			for _, alias := range aliases {
+				if _, seen := columns[field]; !seen && name == alias {
+					columns[field] = i
+				}
+			}
+		}
+	}
+	for _, required := range []string{"wpm", "accuracy", "timestamp"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV is missing a '%s' column", required)
+		}
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read CSV line %d: %v", line, err)
+		}
+
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		record, err := importRow(get, source, resolve)
+		if err != nil {
+			return nil, fmt.Errorf("CSV line %d: %v", line, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// importRow converts a single CSV row into a tagged record
+func importRow(get func(string) string, source string, resolve LanguageResolver) (Record, error) {
+	wpm, err := strconv.ParseFloat(get("wpm"), 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid wpm '%s'", get("wpm"))
+	}
+	acc, err := strconv.ParseFloat(strings.TrimSuffix(get("accuracy"), "%"), 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("invalid accuracy '%s'", get("accuracy"))
+	}
+	timestamp, err := parseTimestamp(get("timestamp"))
+	if err != nil {
+		return Record{}, err
+	}
+
+	record := Record{
+		Timestamp: timestamp,
+		WPM:       wpm,
+		Accuracy:  acc,
+		Source:    source,
+		Tags:      []string{TagImported},
+	}
+
+	// Duration comes from an explicit column or from time-mode tests
+	mode := strings.ToLower(get("mode"))
+	if d, err := strconv.ParseFloat(get("duration"), 64); err == nil {
+		record.Duration = int(d + 0.5)
+	} else if mode == ModeTime {
+		record.Duration, _ = strconv.Atoi(get("mode2"))
+	}
+
+	switch mode {
+	case "", ModeTime:
+		record.Mode = ModeTime
+	default:
+		record.Mode = ModeOther
+		record.Tags = append(record.Tags, TagUnknownMode, "mode:"+mode)
+	}
+
+	language := strings.ToLower(get("language"))
+	if language == "" {
+		language = "english"
+	}
+	if code, ok := resolve(language); ok {
+		record.Language = code
+	} else {
+		record.Language = LanguageOther
+		record.Tags = append(record.Tags, TagUnknownLanguage, "lang:"+language)
+	}
+
+	// A stable ID lets re-imports of the same file be recognized as duplicates.
+	// The timestamp is hashed as written, since times without an offset are
+	// read in the local time zone, which may differ between imports.
+	if id := get("id"); id != "" {
+		record.ID = "imp-" + id
+	} else {
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s|%.2f|%.2f|%s|%s|%s",
+			strings.TrimSpace(get("timestamp")), wpm, acc, mode, get("mode2"), language)))
+		record.ID = "imp-" + hex.EncodeToString(sum[:])[:12]
+	}
+
+	return record, nil
+}
+
+// parseTimestamp accepts Unix seconds or milliseconds and common date formats
+func parseTimestamp(value string) (time.Time, error) {
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n > 1e11 {
+			return time.UnixMilli(n), nil
+		}
+		return time.Unix(n, 0), nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp '%s'", value)
+}
+
+// Deduplicate drops incoming records whose ID is already present in existing
+// or earlier in incoming
+func Deduplicate(existing, incoming []Record) (fresh []Record, duplicates int) {
+	seen := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		seen[r.ID] = true
+	}
+	for _, r := range incoming {
+		if seen[r.ID] {
+			duplicates++
+			continue
+		}
+		seen[r.ID] = true
+		fresh = append(fresh, r)
+	}
+	return fresh, duplicates
+}
@@ -0,0 +1,192 @@
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+)
+
+// Record is a single saved typing test result
+type Record struct {
+	ID         string           `json:"id"`
+	Timestamp  time.Time        `json:"timestamp"`
+	WPM        float64          `json:"wpm"`
+	Accuracy   float64          `json:"accuracy"`
+	Duration   int              `json:"duration"`
+	Language   string           `json:"language"`
+	Mode       string           `json:"mode,omitempty"`
+	Layout     string           `json:"layout,omitempty"`     // Emulated keyboard layout, empty when typing natively
+	Source     string           `json:"source,omitempty"`     // Where an imported record came from, empty for native results
+	Difficulty string           `json:"difficulty,omitempty"` // Empty for normal difficulty
+	Failure    string           `json:"failure,omitempty"`    // Why the test failed early, empty if it ran its course
+	Visibility string           `json:"visibility,omitempty"` // Blind, memory or hidden, empty when all text was shown
+	Funbox     []string         `json:"funbox,omitempty"`     // Text transforms, which keep a result off the best scores
+	Tags       []string         `json:"tags,omitempty"`
+	Keystrokes []game.Keystroke `json:"keystrokes,omitempty"`
+}
+
+// Test modes recorded with each result
+const (
+	ModeTime    = "time"    // Standard timed test
+	ModeSymbols = "symbols" // Timed symbol and operator drill
+	ModeShell   = "shell"   // Timed full command lines, each completed with Enter
+)
+
+// NewRecord builds a history record from the stats of a finished game
+func NewRecord(g *game.TypingGame, stats game.TypingStats, language, mode string) Record {
+	now := time.Now()
+	r := Record{
+		ID:         strconv.FormatInt(now.UnixMilli(), 36),
+		Timestamp:  now,
+		WPM:        stats.WPM,
+		Accuracy:   stats.Accuracy,
+		Duration:   g.Duration,
+		Language:   language,
+		Mode:       mode,
+		Keystrokes: g.Keystrokes,
+		Failure:    g.FailReason,
+	}
+	// A failed run lasted only until the failure
+	if g.FailReason != "" {
+		r.Duration = max(1, int(math.Round(stats.TimeElapsed.Seconds())))
+	}
+	if g.Difficulty != game.DifficultyNormal {
+		r.Difficulty = g.Difficulty.String()
+	}
+	return r
+}
+
+// Store persists records as JSON lines in a single file
+type Store struct {
+	path string
+}
+
+// NewStore creates a Store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// OpenDefault creates a Store at the default history location
+func OpenDefault() (*Store, error) {
+	path, err := DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(path), nil
+}
+
+// DefaultPath returns $XDG_DATA_HOME/typtea/history.jsonl, falling back to ~/.local/share
+func DefaultPath() (string, error) {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory: %v", err)
+		}
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dataHome, "typtea", "history.jsonl"), nil
+}
+
+// Path returns the file backing the store
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads all records from the store, oldest first
+func (s *Store) Load() ([]Record, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not open history: %v", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("could not parse history line %d: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read history: %v", err)
+	}
+	return records, nil
+}
+
+// Append adds a record to the end of the store, creating it if needed
+func (s *Store) Append(r Record) error {
+	return s.AppendAll([]Record{r})
+}
+
+// AppendAll adds several records to the end of the store in one write
+func (s *Store) AppendAll(records []Record) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("could not create history directory: %v", err)
+	}
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("could not open history: %v", err)
+	}
+	defer file.Close()
+
+	var data []byte
+	for _, r := range records {
+		line, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("could not encode record: %v", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("could not write history: %v", err)
+	}
+	return nil
+}
+
+// AllKeystrokes flattens the keystroke logs of the given records
+func AllKeystrokes(records []Record) []game.Keystroke {
+	var keystrokes []game.Keystroke
+	for _, r := range records {
+		keystrokes = append(keystrokes, r.Keystrokes...)
+	}
+	return keystrokes
+}
+
+// Sessions returns the keystroke log of each record as a separate session
+func Sessions(records []Record) [][]game.Keystroke {
+	sessions := make([][]game.Keystroke, 0, len(records))
+	for _, r := range records {
+		if len(r.Keystrokes) > 0 {
+			sessions = append(sessions, r.Keystrokes)
+		}
+	}
+	return sessions
+}
+
+// Find returns the record with the given ID
+func Find(records []Record, id string) (Record, bool) {
+	for _, r := range records {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return Record{}, false
+}
@@ -0,0 +1,95 @@
+package keyboard
+
+import "fmt"
+
+// Finger identifies the finger that presses a key
+type Finger int
+
+const (
+	LeftPinky Finger = iota
+	LeftRing
+	LeftMiddle
+	LeftIndex
+	RightIndex
+	RightMiddle
+	RightRing
+	RightPinky
+	Thumb
+)
+
+// Fingers lists every finger from the left pinky to the thumbs
+var Fingers = []Finger{LeftPinky, LeftRing, LeftMiddle, LeftIndex, RightIndex, RightMiddle, RightRing, RightPinky, Thumb}
+
+// fingerNames holds the display name of each finger
+var fingerNames = map[Finger]string{
+	LeftPinky:   "left pinky",
+	LeftRing:    "left ring",
+	LeftMiddle:  "left middle",
+	LeftIndex:   "left index",
+	RightIndex:  "right index",
+	RightMiddle: "right middle",
+	RightRing:   "right ring",
+	RightPinky:  "right pinky",
+	Thumb:       "thumb",
+}
+
+// String returns the display name of the finger
+func (f Finger) String() string {
+	if name, ok := fingerNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("finger(%d)", int(f))
+}
+
+// MarshalText encodes the finger by name
+func (f Finger) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// Hand identifies which hand a finger belongs to
+type Hand int
+
+const (
+	LeftHand Hand = iota
+	RightHand
+	EitherHand // Thumbs on the space bar
+)
+
+// Hand returns the hand the finger belongs to
+func (f Finger) Hand() Hand {
+	switch {
+	case f == Thumb:
+		return EitherHand
+	case f <= LeftIndex:
+		return LeftHand
+	}
+	return RightHand
+}
+
+// standardFingers is the touch typing assignment for a row-staggered keyboard,
+// one digit per key (0 left pinky … 7 right pinky), aligned with Layout.Rows
+var standardFingers = []string{"0012334456777", "0123344567777", "01233445677", "0123344567"}
+
+// FingerFor returns the finger that types char on this layout
+func (l Layout) FingerFor(char rune) (Finger, bool) {
+	if char == ' ' {
+		return Thumb, true
+	}
+	row, col, _, ok := l.Position(char)
+	if !ok {
+		return 0, false
+	}
+
+	fingers := l.Fingers
+	if len(fingers) == 0 {
+		fingers = standardFingers
+	}
+	if row >= len(fingers) || col >= len(fingers[row]) {
+		return 0, false
+	}
+	digit := fingers[row][col]
+	if digit < '0' || digit > '7' {
+		return 0, false
+	}
+	return Finger(digit - '0'), true
+}
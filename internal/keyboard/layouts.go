@@ -0,0 +1,159 @@
+package keyboard
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Layout describes the physical arrangement of characters on a keyboard
+type Layout struct {
+	Name    string   `toml:"name"`
+	Rows    []string `toml:"rows"`    // Unshifted characters, from the number row down
+	Shifted []string `toml:"shifted"` // Shifted characters, aligned with Rows
+	Fingers []string `toml:"fingers"` // Optional finger per key (0 left pinky … 7 right pinky), aligned with Rows
+}
+
+// layouts holds the built-in keyboard layouts keyed by their lowercase name
+var layouts = map[string]Layout{
+	"qwerty": {
+		Name:    "qwerty",
+		Rows:    []string{"`1234567890-=", "qwertyuiop[]\\", "asdfghjkl;'", "zxcvbnm,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWERTYUIOP{}|", "ASDFGHJKL:\"", "ZXCVBNM<>?"},
+	},
+	"dvorak": {
+		Name:    "dvorak",
+		Rows:    []string{"`1234567890[]", "',.pyfgcrl/=\\", "aoeuidhtns-", ";qjkxbmwvz"},
+		Shifted: []string{"~!@#$%^&*(){}", "\"<>PYFGCRL?+|", "AOEUIDHTNS_", ":QJKXBMWVZ"},
+	},
+	"colemak": {
+		Name:    "colemak",
+		Rows:    []string{"`1234567890-=", "qwfpgjluy;[]\\", "arstdhneio'", "zxcvbkm,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWFPGJLUY:{}|", "ARSTDHNEIO\"", "ZXCVBKM<>?"},
+	},
+	"colemak-dh": {
+		Name:    "colemak-dh",
+		Rows:    []string{"`1234567890-=", "qwfpbjluy;[]\\", "arstgmneio'", "xcdvzkh,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QWFPBJLUY:{}|", "ARSTGMNEIO\"", "XCDVZKH<>?"},
+	},
+	"workman": {
+		Name:    "workman",
+		Rows:    []string{"`1234567890-=", "qdrwbjfup;[]\\", "ashtgyneoi'", "zxmcvkl,./"},
+		Shifted: []string{"~!@#$%^&*()_+", "QDRWBJFUP:{}|", "ASHTGYNEOI\"", "ZXMCVKL<>?"},
+	},
+}
+
+// Physical is the layout the operating system is assumed to type with
+const Physical = "qwerty"
+
+// Resolve returns the built-in layout with the given name, or loads a custom
+// layout when name is a path to a TOML file
+func Resolve(name string) (Layout, error) {
+	if strings.HasSuffix(strings.ToLower(name), ".toml") || strings.ContainsRune(name, filepath.Separator) {
+		return LoadFile(name)
+	}
+	return Get(name)
+}
+
+// LoadFile reads a custom layout from a TOML file with name, rows and shifted
+// keys. Rows must line up key for key with the QWERTY rows they replace.
+func LoadFile(path string) (Layout, error) {
+	var layout Layout
+	if _, err := toml.DecodeFile(path, &layout); err != nil {
+		return Layout{}, fmt.Errorf("could not read keyboard layout %s: %v", path, err)
+	}
+	if layout.Name == "" {
+		layout.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	physical := layouts[Physical]
+	if len(layout.Rows) != len(physical.Rows) || len(layout.Shifted) != len(physical.Shifted) {
+		return Layout{}, fmt.Errorf("keyboard layout %s must have %d rows and %d shifted rows", path, len(physical.Rows), len(physical.Shifted))
+	}
+	for i := range physical.Rows {
+		want := utf8.RuneCountInString(physical.Rows[i])
+		if utf8.RuneCountInString(layout.Rows[i]) != want || utf8.RuneCountInString(layout.Shifted[i]) != want {
+			return Layout{}, fmt.Errorf("keyboard layout %s: row %d must have %d keys", path, i+1, want)
+		}
+		if len(layout.Fingers) > 0 && (len(layout.Fingers) != len(physical.Rows) || utf8.RuneCountInString(layout.Fingers[i]) != want) {
+			return Layout{}, fmt.Errorf("keyboard layout %s: fingers must give one digit per key in each row", path)
+		}
+	}
+	return layout, nil
+}
+
+// Translate converts a character typed on the physical QWERTY keyboard into the
+// character the same key produces on layout l. Characters without a key are
+// returned unchanged.
+func (l Layout) Translate(char rune) rune {
+	row, col, shifted, ok := layouts[Physical].Position(char)
+	if !ok || row >= len(l.Rows) {
+		return char
+	}
+	keys := l.Rows[row]
+	if shifted {
+		keys = l.Shifted[row]
+	}
+	if runes := []rune(keys); col < len(runes) {
+		return runes[col]
+	}
+	return char
+}
+
+// Get returns the built-in layout with the given name
+func Get(name string) (Layout, error) {
+	layout, ok := layouts[strings.ToLower(name)]
+	if !ok {
+		return Layout{}, fmt.Errorf("unknown keyboard layout '%s' (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return layout, nil
+}
+
+// Names returns the sorted names of all built-in layouts
+func Names() []string {
+	names := make([]string, 0, len(layouts))
+	for name := range layouts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Position returns the row and column of the key that produces char, and whether it needs shift
+func (l Layout) Position(char rune) (row, col int, shifted, ok bool) {
+	for r := range l.Rows {
+		if c := indexRune(l.Rows[r], char); c >= 0 {
+			return r, c, false, true
+		}
+		if c := indexRune(l.Shifted[r], char); c >= 0 {
+			return r, c, true, true
+		}
+	}
+	return 0, 0, false, false
+}
+
+// KeyFor returns the unshifted character of the key that produces char
+func (l Layout) KeyFor(char rune) (rune, bool) {
+	if char == ' ' {
+		return ' ', true
+	}
+	row, col, _, ok := l.Position(char)
+	if !ok {
+		return 0, false
+	}
+	return []rune(l.Rows[row])[col], true
+}
+
+// indexRune returns the rune index of char within s, or -1 if not present
+func indexRune(s string, char rune) int {
+	for i, r := range []rune(s) {
+		if r == char {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,263 @@
+package keymap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Action is something the user can trigger with a key
+type Action string
+
+// Actions available everywhere
+const (
+	Quit    Action = "quit"
+	Restart Action = "restart" // New test with fresh text
+	Retry   Action = "retry"   // New test with the same text
+	Help    Action = "help"
+)
+
+// Actions available on the results screen only
+const (
+	NextPage      Action = "next_page"
+	HeatmapMetric Action = "heatmap_metric"
+	HeatmapLayout Action = "heatmap_keyboard"
+	SaveCard      Action = "save_card"
+	CopyCard      Action = "copy_card"
+)
+
+// Context is the screen a key is pressed on
+type Context int
+
+const (
+	ContextTest Context = iota
+	ContextResults
+)
+
+// binding is the static description of an action
+type binding struct {
+	action      Action
+	description string
+	keys        []string
+	resultsOnly bool   // Results-only actions may use typeable keys
+	fallback    string // Used when user bindings take all the default keys
+}
+
+// defaults lists every action in help order with its default keys
+var defaults = []binding{
+	{Quit, "quit", []string{"esc", "ctrl+c"}, false, ""},
+	{Restart, "restart with new text", []string{"enter"}, false, ""},
+	{Retry, "restart with same text", []string{"ctrl+r"}, false, ""},
+	{Help, "toggle this help", []string{"f1"}, false, ""},
+	{NextPage, "next results page", []string{"tab"}, true, "n"},
+	{HeatmapMetric, "switch heatmap metric", []string{"h"}, true, ""},
+	{HeatmapLayout, "switch heatmap keyboard", []string{"k"}, true, ""},
+	{SaveCard, "save result card", []string{"s"}, true, ""},
+	{CopyCard, "copy result card", []string{"y"}, true, ""},
+}
+
+// resultsKeys only act on the results screen, whatever they are bound to, so
+// a stray press can't throw away a test in progress
+var resultsKeys = map[string]bool{
+	"enter": true,
+}
+
+// reservedKeys are handled by the typing test itself and can't be rebound
+var reservedKeys = map[string]bool{
+	"backspace": true,
+	" ":         true,
+	"space":     true,
+}
+
+// Keymap resolves key presses to actions
+type Keymap struct {
+	keys    map[Action][]string
+	actions map[string]Action
+}
+
+// IsAction reports whether name is a known action
+func IsAction(name string) bool {
+	for _, b := range defaults {
+		if string(b.action) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Actions returns the names of all actions in help order
+func Actions() []string {
+	names := make([]string, len(defaults))
+	for i, b := range defaults {
+		names[i] = string(b.action)
+	}
+	return names
+}
+
+// Default returns the built-in keymap
+func Default() Keymap {
+	km, _ := New(nil)
+	return km
+}
+
+// New builds a keymap from the defaults and user overrides. Each override
+// value is a comma-separated list of keys, such as "tab" or "esc,ctrl+c".
+// User bindings replace the defaults of their action and take a key away
+// from any other action that had it by default. An action left without keys
+// moves to its fallback key when that is free, or stays unbound.
+func New(overrides map[string]string) (Keymap, error) {
+	km := Keymap{
+		keys:    make(map[Action][]string),
+		actions: make(map[string]Action),
+	}
+
+	userKeys := make(map[Action][]string)
+	claimed := make(map[string]Action)
+	for name, value := range overrides {
+		if !IsAction(name) {
+			return Keymap{}, fmt.Errorf("unknown action '%s' (available: %s)", name, strings.Join(Actions(), ", "))
+		}
+		action := Action(name)
+		for _, key := range parseKeys(value) {
+			if other, taken := claimed[key]; taken {
+				return Keymap{}, fmt.Errorf("key '%s' is bound to both %s and %s", key, other, action)
+			}
+			claimed[key] = action
+			userKeys[action] = append(userKeys[action], key)
+		}
+	}
+
+	for _, b := range defaults {
+		keys, custom := userKeys[b.action]
+		if !custom {
+			for _, key := range b.keys {
+				if _, taken := claimed[key]; !taken {
+					keys = append(keys, key)
+				}
+			}
+			if len(keys) == 0 && b.fallback != "" && !isDefaultKey(b.fallback) {
+				if _, taken := claimed[b.fallback]; !taken {
+					keys = append(keys, b.fallback)
+				}
+			}
+		}
+
+		for _, key := range keys {
+			if reservedKeys[key] {
+				return Keymap{}, fmt.Errorf("key '%s' for %s is reserved for typing", key, b.action)
+			}
+			if !b.resultsOnly && IsTypeable(key) {
+				return Keymap{}, fmt.Errorf("key '%s' for %s is a typeable character and would conflict with the test", key, b.action)
+			}
+			km.actions[key] = b.action
+		}
+		km.keys[b.action] = keys
+	}
+
+	if len(km.keys[Quit]) == 0 {
+		return Keymap{}, fmt.Errorf("quit must be bound to at least one key")
+	}
+	return km, nil
+}
+
+// parseKeys splits a comma-separated key list, keeping a literal "," key
+func parseKeys(value string) []string {
+	if value == "," {
+		return []string{","}
+	}
+	var keys []string
+	for _, key := range strings.Split(value, ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsTypeable reports whether a key produces a character that can appear in test text
+func IsTypeable(key string) bool {
+	runes := []rune(key)
+	return len(runes) == 1 && unicode.IsPrint(runes[0])
+}
+
+// Action returns the action bound to key in the given context
+func (km Keymap) Action(key string, ctx Context) (Action, bool) {
+	action, ok := km.actions[key]
+	if !ok {
+		return "", false
+	}
+	if ctx == ContextTest && (isResultsOnly(action) || resultsKeys[key]) {
+		return "", false
+	}
+	return action, true
+}
+
+// Empty reports whether the keymap has no bindings, as with a zero Keymap
+func (km Keymap) Empty() bool {
+	return len(km.actions) == 0
+}
+
+// Keys returns the keys bound to an action
+func (km Keymap) Keys(action Action) []string {
+	return km.keys[action]
+}
+
+// Conflicts returns the keys bound outside the results screen that the given
+// predicate reports as typeable, for tests that accept keys like enter or tab
+func (km Keymap) Conflicts(typeable func(key string) bool) []string {
+	var conflicts []string
+	for key, action := range km.actions {
+		if !isResultsOnly(action) && typeable(key) {
+			conflicts = append(conflicts, key)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// HelpEntry is a row of the help overlay
+type HelpEntry struct {
+	Keys        string
+	Description string
+	ResultsOnly bool
+}
+
+// Help lists every action with its keys, in display order
+func (km Keymap) Help() []HelpEntry {
+	entries := make([]HelpEntry, 0, len(defaults))
+	for _, b := range defaults {
+		keys := km.keys[b.action]
+		if len(keys) == 0 {
+			continue
+		}
+		entries = append(entries, HelpEntry{
+			Keys:        strings.Join(keys, " / "),
+			Description: b.description,
+			ResultsOnly: b.resultsOnly || !slices.ContainsFunc(keys, func(key string) bool { return !resultsKeys[key] }),
+		})
+	}
+	return entries
+}
+
+// isDefaultKey reports whether any action has key among its default keys
+func isDefaultKey(key string) bool {
+	for _, b := range defaults {
+		if slices.Contains(b.keys, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isResultsOnly reports whether an action only applies on the results screen
+func isResultsOnly(action Action) bool {
+	for _, b := range defaults {
+		if b.action == action {
+			return b.resultsOnly
+		}
+	}
+	return false
+}
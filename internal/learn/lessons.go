@@ -0,0 +1,170 @@
+package learn
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+)
+
+// Lesson is a step of the course that drills a restricted set of characters
+type Lesson struct {
+	ID          string // Unique per layout, e.g. colemak/home-row
+	Title       string
+	Keys        string  // Characters introduced by this lesson
+	Chars       string  // Every character drills may use, including earlier lessons
+	MinWPM      float64 // Pass criteria
+	MinAccuracy float64
+}
+
+// codeSymbols are the characters practiced by the final lesson
+const codeSymbols = "{}[]()<>=+-*/_|&;:'\"`~\\"
+
+// Curriculum builds the course for a keyboard layout, so that rows follow the
+// layout being learned: home row, top row, bottom row, numbers, symbols and
+// finally the symbols used in code
+func Curriculum(layout keyboard.Layout) []Lesson {
+	steps := []struct {
+		id, title string
+		keys      string
+		wpm, acc  float64
+	}{
+		{"home-row", "home row", firstKeys(layout.Rows[2], 10), 15, 90},
+		{"top-row", "top row", firstKeys(layout.Rows[1], 10), 18, 90},
+		{"bottom-row", "bottom row", firstKeys(layout.Rows[3], 10), 18, 90},
+		{"numbers", "numbers", firstKeys(strings.TrimLeft(layout.Rows[0], "`~"), 10), 15, 90},
+		{"symbols", "symbols", firstKeys(strings.TrimLeft(layout.Shifted[0], "`~"), 10), 12, 88},
+		{"code-symbols", "code symbols", codeSymbols, 12, 88},
+	}
+
+	var lessons []Lesson
+	var chars string
+	for _, s := range steps {
+		// Only keep keys that earlier lessons haven't introduced
+		var keys strings.Builder
+		for _, r := range s.keys {
+			if !strings.ContainsRune(chars, r) && !strings.ContainsRune(keys.String(), r) {
+				keys.WriteRune(r)
+			}
+		}
+		// Layouts that put every key of a step on earlier rows get no lesson for it
+		if keys.Len() == 0 {
+			continue
+		}
+		chars += keys.String()
+		lessons = append(lessons, Lesson{
+			ID:          layout.Name + "/" + s.id,
+			Title:       s.title,
+			Keys:        keys.String(),
+			Chars:       chars,
+			MinWPM:      s.wpm,
+			MinAccuracy: s.acc,
+		})
+	}
+	return lessons
+}
+
+// firstKeys returns up to n characters from the start of a key row
+func firstKeys(row string, n int) string {
+	runes := []rune(row)
+	return string(runes[:min(n, len(runes))])
+}
+
+// Passes reports whether a result meets the lesson's pass criteria
+func (l Lesson) Passes(wpm, accuracy float64) bool {
+	return wpm >= l.MinWPM && accuracy >= l.MinAccuracy
+}
+
+// Source returns a word source for drills of this lesson. It mixes real words
+// from dictionary that only use the lesson's characters with generated groups,
+// always favoring the keys the lesson introduces.
+func (l Lesson) Source(dictionary []string) func(count int) []string {
+	var focus, review []string
+	for _, word := range dictionary {
+		word = strings.ToLower(word)
+		if !onlyChars(word, l.Chars) {
+			continue
+		}
+		if strings.ContainsAny(word, l.Keys) {
+			focus = append(focus, word)
+		} else {
+			review = append(review, word)
+		}
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	keys := []rune(l.Keys)
+	letters := lettersOf(l.Chars)
+
+	return func(count int) []string {
+		words := make([]string, count)
+		for i := range words {
+			switch {
+			case len(focus) > 0 && rng.Intn(2) == 0:
+				words[i] = focus[rng.Intn(len(focus))]
+			case len(review) > 0 && !unicode.IsLetter(keys[0]):
+				// Digits and symbols are practiced around familiar words
+				words[i] = decorate(review[rng.Intn(len(review))], keys, rng)
+			default:
+				words[i] = group(keys, letters, rng)
+			}
+		}
+		return words
+	}
+}
+
+// decorate attaches one or two new keys to a word, pairing brackets and quotes
+func decorate(word string, keys []rune, rng *rand.Rand) string {
+	k := keys[rng.Intn(len(keys))]
+	if closing, ok := pairs[k]; ok {
+		return string(k) + word + string(closing)
+	}
+	if rng.Intn(2) == 0 {
+		return string(k) + word
+	}
+	return word + string(k) + string(keys[rng.Intn(len(keys))])
+}
+
+// pairs maps opening brackets and quotes to their closing counterpart
+var pairs = map[rune]rune{'(': ')', '[': ']', '{': '}', '<': '>', '"': '"', '\'': '\'', '`': '`'}
+
+// group generates a 2-5 character drill group with at least one new key
+func group(keys, letters []rune, rng *rand.Rand) string {
+	pool := letters
+	if len(pool) == 0 {
+		pool = keys
+	}
+	g := make([]rune, 2+rng.Intn(4))
+	for i := range g {
+		if rng.Intn(2) == 0 {
+			g[i] = keys[rng.Intn(len(keys))]
+		} else {
+			g[i] = pool[rng.Intn(len(pool))]
+		}
+	}
+	g[rng.Intn(len(g))] = keys[rng.Intn(len(keys))]
+	return string(g)
+}
+
+// onlyChars reports whether every character of word is in allowed
+func onlyChars(word, allowed string) bool {
+	for _, r := range word {
+		if !strings.ContainsRune(allowed, r) {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// lettersOf returns the letters among chars
+func lettersOf(chars string) []rune {
+	var letters []rune
+	for _, r := range chars {
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	return letters
+}
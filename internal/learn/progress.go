@@ -0,0 +1,98 @@
+package learn
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/history"
+)
+
+// Result is the best outcome recorded for a lesson
+type Result struct {
+	Attempts     int        `json:"attempts"`
+	BestWPM      float64    `json:"best_wpm"`
+	BestAccuracy float64    `json:"best_accuracy"`
+	Passed       bool       `json:"passed"`
+	PassedAt     *time.Time `json:"passed_at,omitempty"`
+}
+
+// Progress tracks results for every lesson attempted, keyed by lesson ID
+type Progress struct {
+	Lessons map[string]Result `json:"lessons"`
+	path    string
+}
+
+// DefaultPath returns the progress file, stored next to the history
+func DefaultPath() (string, error) {
+	historyPath, err := history.DefaultPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(historyPath), "learn.json"), nil
+}
+
+// Load reads progress from path, starting fresh if the file doesn't exist
+func Load(path string) (*Progress, error) {
+	p := &Progress{Lessons: make(map[string]Result), path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read lesson progress: %v", err)
+	}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("could not parse lesson progress %s: %v", path, err)
+	}
+	if p.Lessons == nil {
+		p.Lessons = make(map[string]Result)
+	}
+	return p, nil
+}
+
+// Save writes progress back to its file, creating the directory if needed
+func (p *Progress) Save() error {
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return fmt.Errorf("could not create progress directory: %v", err)
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode lesson progress: %v", err)
+	}
+	if err := os.WriteFile(p.path, data, 0o644); err != nil {
+		return fmt.Errorf("could not write lesson progress: %v", err)
+	}
+	return nil
+}
+
+// Record stores an attempt at a lesson and reports whether it passed
+func (p *Progress) Record(l Lesson, wpm, accuracy float64) bool {
+	r := p.Lessons[l.ID]
+	r.Attempts++
+	r.BestWPM = max(r.BestWPM, wpm)
+	r.BestAccuracy = max(r.BestAccuracy, accuracy)
+
+	passed := l.Passes(wpm, accuracy)
+	if passed && !r.Passed {
+		r.Passed = true
+		now := time.Now()
+		r.PassedAt = &now
+	}
+	p.Lessons[l.ID] = r
+	return passed
+}
+
+// Unlocked reports whether lessons[i] may be attempted: the first lesson is
+// always open, later ones once the previous lesson has been passed
+func (p *Progress) Unlocked(lessons []Lesson, i int) bool {
+	return i == 0 || p.Lessons[lessons[i-1].ID].Passed
+}
+
+// Reset forgets every recorded result
+func (p *Progress) Reset() {
+	p.Lessons = make(map[string]Result)
+}
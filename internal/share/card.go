@@ -0,0 +1,219 @@
+package share
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/history"
+	"github.com/ashish0kumar/typtea/internal/theme"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const dateLayout = "2006-01-02 15:04"
+
+// sparkWidth is the maximum number of bars in the text chart
+const sparkWidth = 40
+
+// sparkBlocks are the bar heights used for the text chart
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// SVG card palette. The card is always drawn on a dark background, so
+// ApplyTheme only takes the dark variants of theme colors given in hex.
+var (
+	svgBackground = "#1e1e2e"
+	svgText       = "#cdd6f4"
+	svgMuted      = "#6c7086"
+	svgAccent     = "#89b4fa"
+)
+
+// Styles for the ANSI card. Colors are filled in by ApplyTheme.
+var (
+	cardStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, 3)
+
+	cardTitleStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	cardMutedStyle = lipgloss.NewStyle()
+
+	cardValueStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	cardChartStyle = lipgloss.NewStyle()
+)
+
+// ApplyTheme sets the colors of the ANSI card, and of the SVG card where the
+// theme gives hex colors
+func ApplyTheme(t theme.Theme) {
+	cardStyle = cardStyle.BorderForeground(t.CardBorder.Adaptive())
+	cardTitleStyle = cardTitleStyle.Foreground(t.CardAccent.Adaptive())
+	cardMutedStyle = cardMutedStyle.Foreground(t.Muted.Adaptive())
+	cardChartStyle = cardChartStyle.Foreground(t.CardAccent.Adaptive())
+
+	if strings.HasPrefix(t.CardAccent.Dark, "#") {
+		svgAccent = t.CardAccent.Dark
+	}
+	if strings.HasPrefix(t.Muted.Dark, "#") {
+		svgMuted = t.Muted.Dark
+	}
+}
+
+// cardStat is a labelled figure shown on the card
+type cardStat struct {
+	label string
+	value string
+}
+
+// cardStats returns the figures shown on every card, in display order
+func cardStats(r history.Record) []cardStat {
+	return []cardStat{
+		{"wpm", fmt.Sprintf("%.0f", r.WPM)},
+		{"acc", fmt.Sprintf("%.0f%%", r.Accuracy)},
+		{"time", fmt.Sprintf("%ds", r.Duration)},
+		{"lang", r.Language},
+	}
+}
+
+// ANSI renders the result as a colored text card for the terminal
+func ANSI(r history.Record) string {
+	var columns []string
+	for i, stat := range cardStats(r) {
+		if i > 0 {
+			columns = append(columns, "    ")
+		}
+		columns = append(columns, lipgloss.JoinVertical(
+			lipgloss.Left,
+			cardMutedStyle.Render(stat.label),
+			cardValueStyle.Render(stat.value),
+		))
+	}
+
+	header := cardTitleStyle.Render("typtea") + "  " + cardMutedStyle.Render(r.Timestamp.Format(dateLayout))
+	sections := []string{header, "", lipgloss.JoinHorizontal(lipgloss.Top, columns...)}
+
+	if chart := sparkline(game.WPMSeries(r.Keystrokes)); chart != "" {
+		sections = append(sections, "", cardChartStyle.Render(chart))
+	}
+
+	return cardStyle.Render(lipgloss.JoinVertical(lipgloss.Left, sections...))
+}
+
+// Plain renders the ANSI card without escape sequences, for pasting into chat
+func Plain(r history.Record) string {
+	return ansi.Strip(ANSI(r))
+}
+
+// sparkline draws the series as a row of block characters
+func sparkline(series []float64) string {
+	if len(series) == 0 {
+		return ""
+	}
+	series = resample(series, sparkWidth)
+
+	maxVal := 0.0
+	for _, v := range series {
+		maxVal = max(maxVal, v)
+	}
+
+	var b strings.Builder
+	for _, v := range series {
+		level := 0
+		if maxVal > 0 {
+			level = int(v / maxVal * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[level])
+	}
+	return b.String()
+}
+
+// resample averages the series down to at most n points
+func resample(series []float64, n int) []float64 {
+	if len(series) <= n {
+		return series
+	}
+	out := make([]float64, n)
+	for i := range out {
+		start, end := i*len(series)/n, (i+1)*len(series)/n
+		sum := 0.0
+		for _, v := range series[start:end] {
+			sum += v
+		}
+		out[i] = sum / float64(end-start)
+	}
+	return out
+}
+
+// SVG renders the result as a self-contained SVG image
+func SVG(r history.Record) string {
+	const width, height = 640, 320
+	const chartX, chartY, chartW, chartH = 40, 190, 560, 90
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="ui-monospace, SFMono-Regular, Menlo, Consolas, monospace">`+"\n", width, height, width, height)
+	fmt.Fprintf(&b, `  <rect width="100%%" height="100%%" rx="16" fill="%s"/>`+"\n", svgBackground)
+	fmt.Fprintf(&b, `  <text x="40" y="52" font-size="22" font-weight="bold" fill="%s">typtea</text>`+"\n", svgAccent)
+	fmt.Fprintf(&b, `  <text x="600" y="52" font-size="16" text-anchor="end" fill="%s">%s</text>`+"\n", svgMuted, html.EscapeString(r.Timestamp.Format(dateLayout)))
+
+	for i, stat := range cardStats(r) {
+		x := 40 + i*140
+		fmt.Fprintf(&b, `  <text x="%d" y="105" font-size="16" fill="%s">%s</text>`+"\n", x, svgMuted, stat.label)
+		fmt.Fprintf(&b, `  <text x="%d" y="150" font-size="40" font-weight="bold" fill="%s">%s</text>`+"\n", x, svgText, html.EscapeString(stat.value))
+	}
+
+	series := game.WPMSeries(r.Keystrokes)
+	if len(series) > 1 {
+		maxVal := 0.0
+		for _, v := range series {
+			maxVal = max(maxVal, v)
+		}
+		if maxVal == 0 {
+			maxVal = 1
+		}
+
+		points := make([]string, len(series))
+		for i, v := range series {
+			x := chartX + float64(i)/float64(len(series)-1)*chartW
+			y := chartY + chartH - v/maxVal*chartH
+			points[i] = fmt.Sprintf("%.1f,%.1f", x, y)
+		}
+		fmt.Fprintf(&b, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1"/>`+"\n", chartX, chartY+chartH, chartX+chartW, chartY+chartH, svgMuted)
+		fmt.Fprintf(&b, `  <polyline points="%s" fill="none" stroke="%s" stroke-width="3" stroke-linejoin="round" stroke-linecap="round"/>`+"\n", strings.Join(points, " "), svgAccent)
+		fmt.Fprintf(&b, `  <text x="%d" y="%d" font-size="12" fill="%s">%.0f wpm</text>`+"\n", chartX, chartY-8, svgMuted, maxVal)
+	}
+
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// WriteFiles saves the SVG and ANSI cards for a record into dir and returns their paths
+func WriteFiles(r history.Record, dir string) (svgPath, ansiPath string, err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", "", fmt.Errorf("could not create output directory: %v", err)
+	}
+
+	base := filepath.Join(dir, "typtea-"+r.ID)
+	svgPath, ansiPath = base+".svg", base+".ans"
+
+	if err := os.WriteFile(svgPath, []byte(SVG(r)), 0o644); err != nil {
+		return "", "", fmt.Errorf("could not write SVG card: %v", err)
+	}
+	if err := os.WriteFile(ansiPath, []byte(ANSI(r)+"\n"), 0o644); err != nil {
+		return "", "", fmt.Errorf("could not write text card: %v", err)
+	}
+	return svgPath, ansiPath, nil
+}
+
+// CopyToClipboard sends text to the terminal's clipboard using OSC52
+func CopyToClipboard(w io.Writer, text string) error {
+	_, err := osc52.New(text).WriteTo(w)
+	return err
+}
@@ -0,0 +1,136 @@
+package syntax
+
+// spec describes the lexical syntax of a language
+type spec struct {
+	comments []string    // Line comment markers
+	blocks   [][2]string // Block comment delimiters
+	quotes   string      // String delimiters
+	extra    string      // Identifier characters besides letters, digits and _
+	sigils   string      // Words starting with one of these are always keywords, like \begin
+	fold     bool        // Keywords are case-insensitive
+	keywords string      // Reserved words, separated by spaces
+}
+
+// Comment and keyword sets shared by several languages
+var (
+	cComments    = []string{"//"}
+	cBlocks      = [][2]string{{"/*", "*/"}}
+	cKeywords    = "auto break case char const continue default do double else enum extern float for goto if inline int long register restrict return short signed sizeof static struct switch typedef union unsigned void volatile while NULL"
+	jsKeywords   = "async await break case catch class const continue debugger default delete do else export extends false finally for function if import in instanceof let new null of return super switch this throw true try typeof undefined var void while with yield"
+	lispKeywords = "defun defvar defparameter defmacro defconst let let* lambda if cond when unless progn setq setf loop do dolist dotimes quote function and or not nil t"
+)
+
+// languages maps language pack codes to their syntax. Packs without an
+// entry, such as natural languages, are not highlighted.
+var languages = map[string]spec{
+	"go": {
+		comments: cComments, blocks: cBlocks, quotes: "\"'`",
+		keywords: "break case chan const continue default defer else fallthrough for func go goto if import interface map package range return select struct switch type var true false nil iota",
+	},
+	"c":   {comments: cComments, blocks: cBlocks, quotes: "\"'", extra: "#", keywords: cKeywords + " #include #define #ifdef #ifndef #endif"},
+	"c++": {comments: cComments, blocks: cBlocks, quotes: "\"'", extra: "#", keywords: cKeywords + " #include #define bool class catch constexpr delete explicit false friend namespace new nullptr operator override private protected public template this throw true try typename using virtual"},
+	"java": {
+		comments: cComments, blocks: cBlocks, quotes: "\"'", sigils: "@",
+		keywords: "abstract assert boolean break byte case catch char class const continue default do double else enum extends final finally float for if implements import instanceof int interface long native new package private protected public record return short static super switch synchronized this throw throws try var void volatile while true false null",
+	},
+	"csharp": {
+		comments: cComments, blocks: cBlocks, quotes: "\"'",
+		keywords: "abstract as async await base bool break byte case catch char class const continue decimal default delegate do double else enum event explicit extern false finally float for foreach if implicit in int interface internal is lock long namespace new null object out override params private protected public readonly ref return sealed short static string struct switch this throw true try typeof uint ulong using var virtual void while",
+	},
+	"javascript": {comments: cComments, blocks: cBlocks, quotes: "\"'`", extra: "$", keywords: jsKeywords},
+	"typescript": {
+		comments: cComments, blocks: cBlocks, quotes: "\"'`", extra: "$",
+		keywords: jsKeywords + " abstract any as boolean declare enum implements interface keyof namespace never number private protected public readonly string type unknown",
+	},
+	"rust": {
+		comments: cComments, blocks: cBlocks, quotes: "\"",
+		keywords: "as async await break const continue crate dyn else enum extern false fn for if impl in let loop match mod move mut pub ref return self Self static struct super trait true type unsafe use where while",
+	},
+	"swift": {
+		comments: cComments, blocks: cBlocks, quotes: "\"", sigils: "@",
+		keywords: "as associatedtype async await break case catch class continue default defer deinit do else enum extension fallthrough false fileprivate for func guard if import in init inout internal is let nil open operator private protocol public repeat rethrows return self Self static struct subscript super switch throw throws true try typealias var where while",
+	},
+	"zig": {
+		comments: cComments, quotes: "\"'", sigils: "@",
+		keywords: "align allowzero and anyframe anytype asm async await break callconv catch comptime const continue defer else enum errdefer error export extern false fn for if inline noalias noinline nosuspend null opaque or orelse packed pub resume return struct suspend switch test threadlocal true try undefined union unreachable usingnamespace var volatile while",
+	},
+	"vala": {
+		comments: cComments, blocks: cBlocks, quotes: "\"'",
+		keywords: "abstract as async base break case catch class const construct continue default delegate delete do else enum errordomain false finally for foreach get if in inline interface internal is lock namespace new null out override owned private protected public ref return set signal sizeof static struct switch this throw throws true try typeof unowned using var virtual void weak while yield",
+	},
+	"php": {
+		comments: []string{"//", "#"}, blocks: cBlocks, quotes: "\"'", extra: "$",
+		keywords: "abstract and array as break callable case catch class clone const continue declare default do echo else elseif empty extends false final finally fn for foreach function global if implements include instanceof interface isset list match namespace new null or print private protected public readonly require return static switch throw trait true try unset use var while yield",
+	},
+	"python": {
+		comments: []string{"#"}, quotes: "\"'", sigils: "@",
+		keywords: "False None True and as assert async await break case class continue def del elif else except finally for from global if import in is lambda match nonlocal not or pass raise return self try while with yield",
+	},
+	"ruby": {
+		comments: []string{"#"}, quotes: "\"'", extra: "?!", sigils: ":",
+		keywords: "alias and begin break case class def defined? do else elsif end ensure false for if in module next nil not or redo rescue retry return self super then true undef unless until when while yield",
+	},
+	"crystal": {
+		comments: []string{"#"}, quotes: "\"'", extra: "?!",
+		keywords: "abstract alias annotation as begin break case class def do else elsif end ensure enum extend false for fun if in include lib macro module next nil of out private protected require rescue return select self struct super then true type union unless until when while with yield",
+	},
+	"perl": {
+		comments: []string{"#"}, quotes: "\"'", extra: "$@%",
+		keywords: "my our local sub if elsif else unless while until for foreach last next redo return use package require do eval and or not",
+	},
+	"r": {
+		comments: []string{"#"}, quotes: "\"'", extra: ".",
+		keywords: "if else repeat while function for in next break TRUE FALSE NULL Inf NaN NA library return",
+	},
+	"julia": {
+		comments: []string{"#"}, blocks: [][2]string{{"#=", "=#"}}, quotes: "\"", extra: "!", sigils: "@",
+		keywords: "abstract baremodule begin break catch const continue do else elseif end export false finally for function global if import let local macro module mutable quote return struct true try using while",
+	},
+	"bash": {
+		comments: []string{"#"}, quotes: "\"'", sigils: "$",
+		keywords: "if then else elif fi case esac for select while until do done in function time return export local readonly declare",
+	},
+	"powershell": {
+		comments: []string{"#"}, blocks: [][2]string{{"<#", "#>"}}, quotes: "\"'", extra: "-", sigils: "$", fold: true,
+		keywords: "begin break catch class continue data do dynamicparam else elseif end exit filter finally for foreach from function if in param process return switch throw trap try until using while",
+	},
+	"lua": {
+		comments: []string{"--"}, blocks: [][2]string{{"--[[", "]]"}}, quotes: "\"'",
+		keywords: "and break do else elseif end false for function goto if in local nil not or repeat return then true until while",
+	},
+	"sql": {
+		comments: []string{"--"}, blocks: cBlocks, quotes: "'", fold: true,
+		keywords: "add all alter and as asc between by case create default delete desc distinct drop else end exists foreign from group having in index inner insert into is join key left like limit not null offset on or order outer primary references right select set table then union update values when where",
+	},
+	"haskell": {
+		comments: []string{"--"}, blocks: [][2]string{{"{-", "-}"}}, quotes: "\"", extra: "'",
+		keywords: "case class data default deriving do else foreign if import in infix infixl infixr instance let module newtype of then type where",
+	},
+	"ocaml": {
+		blocks: [][2]string{{"(*", "*)"}}, quotes: "\"", extra: "'",
+		keywords: "and as assert begin class constraint do done downto else end exception external false for fun function functor if in include inherit initializer lazy let match method module mutable new object of open or private rec sig struct then to true try type val virtual when while with",
+	},
+	"erlang": {
+		comments: []string{"%"}, quotes: "\"",
+		keywords: "after and andalso band begin bnot bor bsl bsr bxor case catch cond div end fun if let not of or orelse receive rem try when xor",
+	},
+	"lisp":  {comments: []string{";"}, quotes: "\"", extra: "-*+!?<>=/", sigils: ":", keywords: lispKeywords},
+	"emacs": {comments: []string{";"}, quotes: "\"", extra: "-*+!?<>=/", sigils: ":", keywords: lispKeywords + " defcustom interactive save-excursion"},
+	"vim": {
+		comments: []string{"\""}, quotes: "'", extra: "!:",
+		keywords: "let unlet if else elseif endif while endwhile for endfor function endfunction return call set echo execute try catch finally endtry augroup autocmd",
+	},
+	"wolfram": {
+		blocks: [][2]string{{"(*", "*)"}}, quotes: "\"",
+		keywords: "Module Block With If Which Switch Table Do For While Return True False Null Function Map Apply",
+	},
+	"tex":  {comments: []string{"%"}, sigils: "\\"},
+	"css":  {blocks: cBlocks, quotes: "\"'", extra: "-", sigils: "@", keywords: "important inherit initial unset none auto"},
+	"scss": {comments: cComments, blocks: cBlocks, quotes: "\"'", extra: "-", sigils: "@$", keywords: "important inherit initial unset none auto"},
+	"html": {
+		blocks: [][2]string{{"<!--", "-->"}}, quotes: "\"'", extra: "-",
+		keywords: "html head body div span a p ul ol li script style link meta title img table tr td form input button header footer nav section",
+	},
+	"json": {quotes: "\"", keywords: "true false null"},
+	"yaml": {comments: []string{"#"}, quotes: "\"'", keywords: "true false null yes no on off"},
+}
@@ -0,0 +1,161 @@
+package syntax
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kind classifies a piece of code for highlighting
+type Kind int
+
+const (
+	Plain Kind = iota
+	Keyword
+	String
+	Number
+	Comment
+)
+
+// Highlighter tokenizes lines of one language
+type Highlighter struct {
+	spec     spec
+	keywords map[string]bool
+}
+
+// For returns the highlighter for a language pack, or false for packs that
+// aren't code, such as natural languages
+func For(lang string) (*Highlighter, bool) {
+	s, ok := languages[strings.ToLower(lang)]
+	if !ok {
+		return nil, false
+	}
+	h := &Highlighter{spec: s, keywords: make(map[string]bool)}
+	for _, k := range strings.Fields(s.keywords) {
+		if s.fold {
+			k = strings.ToLower(k)
+		}
+		h.keywords[k] = true
+	}
+	return h, true
+}
+
+// Kinds classifies each grapheme cluster of a line. Lines are tokenized on
+// their own, so block comments and strings don't carry over to the next line.
+func (h *Highlighter) Kinds(clusters []string) []Kind {
+	kinds := make([]Kind, len(clusters))
+	s := h.spec
+
+	mark := func(from, to int, k Kind) int {
+		for i := from; i < to; i++ {
+			kinds[i] = k
+		}
+		return to
+	}
+
+	for i := 0; i < len(clusters); {
+		r, _ := utf8.DecodeRuneInString(clusters[i])
+		boundary := i == 0 || isSpace(clusters[i-1])
+
+		if end, ok := h.blockComment(clusters, i); ok {
+			i = mark(i, end, Comment)
+			continue
+		}
+		if boundary && h.lineComment(clusters, i) {
+			i = mark(i, len(clusters), Comment)
+			continue
+		}
+		if strings.ContainsRune(s.quotes, r) {
+			i = mark(i, closingQuote(clusters, i), String)
+			continue
+		}
+		if unicode.IsDigit(r) {
+			end := i + 1
+			for end < len(clusters) && (h.isIdent(clusters[end]) || clusters[end] == ".") {
+				end++
+			}
+			i = mark(i, end, Number)
+			continue
+		}
+		if h.isIdent(clusters[i]) || strings.ContainsRune(s.sigils, r) {
+			end := i + 1
+			for end < len(clusters) && h.isIdent(clusters[end]) {
+				end++
+			}
+			word := strings.Join(clusters[i:end], "")
+			if s.fold {
+				word = strings.ToLower(word)
+			}
+			if h.keywords[word] || strings.ContainsRune(s.sigils, r) {
+				mark(i, end, Keyword)
+			}
+			i = end
+			continue
+		}
+		i++
+	}
+	return kinds
+}
+
+// lineComment reports whether a line comment starts at i
+func (h *Highlighter) lineComment(clusters []string, i int) bool {
+	for _, marker := range h.spec.comments {
+		if hasPrefixAt(clusters, i, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockComment returns the end of a block comment starting at i
+func (h *Highlighter) blockComment(clusters []string, i int) (int, bool) {
+	for _, block := range h.spec.blocks {
+		if !hasPrefixAt(clusters, i, block[0]) {
+			continue
+		}
+		for end := i + utf8.RuneCountInString(block[0]); end < len(clusters); end++ {
+			if hasPrefixAt(clusters, end, block[1]) {
+				return end + utf8.RuneCountInString(block[1]), true
+			}
+		}
+		return len(clusters), true
+	}
+	return 0, false
+}
+
+// closingQuote returns the end of a string opened at i, past its closing
+// quote or at the end of the line if it is unterminated
+func closingQuote(clusters []string, i int) int {
+	quote := clusters[i]
+	for end := i + 1; end < len(clusters); end++ {
+		switch clusters[end] {
+		case "\\":
+			end++
+		case quote:
+			return end + 1
+		}
+	}
+	return len(clusters)
+}
+
+// isIdent reports whether a cluster may be part of an identifier
+func (h *Highlighter) isIdent(cluster string) bool {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || strings.ContainsRune(h.spec.extra, r)
+}
+
+// hasPrefixAt reports whether the clusters from i onwards start with prefix
+func hasPrefixAt(clusters []string, i int, prefix string) bool {
+	for _, r := range prefix {
+		if i >= len(clusters) || clusters[i] != string(r) {
+			return false
+		}
+		i++
+	}
+	return prefix != ""
+}
+
+// isSpace reports whether a cluster is whitespace
+func isSpace(cluster string) bool {
+	return strings.TrimSpace(cluster) == ""
+}
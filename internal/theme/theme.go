@@ -0,0 +1,194 @@
+package theme
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+
+	"github.com/BurntSushi/toml"
+	"github.com/charmbracelet/lipgloss"
+)
+
+//go:embed themes/*.toml
+var embeddedThemes embed.FS
+
+// DefaultName is the theme used when none is configured
+const DefaultName = "default"
+
+// Color is a terminal color with optional separate values for light and dark backgrounds.
+// In theme files it is either a single string or a table with light and dark keys.
+type Color struct {
+	Light string `json:"light" toml:"light"`
+	Dark  string `json:"dark" toml:"dark"`
+}
+
+// Adaptive converts the color into a lipgloss color that follows the terminal background
+func (c Color) Adaptive() lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+}
+
+// UnmarshalTOML accepts either "12" or { light = "4", dark = "12" }
+func (c *Color) UnmarshalTOML(v any) error {
+	switch v := v.(type) {
+	case string:
+		c.Light, c.Dark = v, v
+		return nil
+	case map[string]any:
+		light, _ := v["light"].(string)
+		dark, _ := v["dark"].(string)
+		c.Light, c.Dark = light, dark
+		return nil
+	}
+	return fmt.Errorf("color must be a string or a table with light and dark keys")
+}
+
+// UnmarshalJSON accepts either "12" or {"light": "4", "dark": "12"}
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		c.Light, c.Dark = single, single
+		return nil
+	}
+	type pair Color
+	var p pair
+	if err := json.Unmarshal(data, &p); err != nil {
+		return fmt.Errorf("color must be a string or an object with light and dark keys")
+	}
+	*c = Color(p)
+	return nil
+}
+
+// Theme holds the colors for every styled element of the interface
+type Theme struct {
+	Name         string  `json:"name" toml:"name"`
+	Timer        Color   `json:"timer" toml:"timer"`
+	Typed        Color   `json:"typed" toml:"typed"`
+	Error        Color   `json:"error" toml:"error"`
+	CursorText   Color   `json:"cursor_text" toml:"cursor_text"`
+	CursorBg     Color   `json:"cursor_bg" toml:"cursor_bg"`
+	Muted        Color   `json:"muted" toml:"muted"`
+	ResultLabel  Color   `json:"result_label" toml:"result_label"`
+	ResultValue  Color   `json:"result_value" toml:"result_value"`
+	HeatmapLabel Color   `json:"heatmap_label" toml:"heatmap_label"`
+	HeatmapScale []Color `json:"heatmap_scale" toml:"heatmap_scale"` // Key backgrounds from cool (good) to hot (bad)
+	Keyword      Color   `json:"keyword" toml:"keyword"`             // Syntax colors for untyped code
+	String       Color   `json:"string" toml:"string"`
+	Number       Color   `json:"number" toml:"number"`
+	Comment      Color   `json:"comment" toml:"comment"`
+	CardBorder   Color   `json:"card_border" toml:"card_border"` // Result card frame
+	CardAccent   Color   `json:"card_accent" toml:"card_accent"` // Result card title and chart
+}
+
+// UserDir returns the directory searched for user theme files
+func UserDir() (string, error) {
+	path, err := config.Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "themes"), nil
+}
+
+// Load finds a theme by name, preferring user files over the embedded themes
+func Load(name string) (Theme, error) {
+	name = strings.ToLower(name)
+	if name == "" {
+		name = DefaultName
+	}
+
+	if dir, err := UserDir(); err == nil {
+		for _, ext := range []string{".toml", ".json"} {
+			data, err := os.ReadFile(filepath.Join(dir, name+ext))
+			if err == nil {
+				return parse(name, ext, data)
+			}
+			if !os.IsNotExist(err) {
+				return Theme{}, fmt.Errorf("could not read theme '%s': %v", name, err)
+			}
+		}
+	}
+
+	data, err := embeddedThemes.ReadFile("themes/" + name + ".toml")
+	if err != nil {
+		names, _ := Names()
+		return Theme{}, fmt.Errorf("unknown theme '%s' (available: %s)", name, strings.Join(names, ", "))
+	}
+	return parse(name, ".toml", data)
+}
+
+// Default returns the embedded default theme, ignoring user theme files
+func Default() (Theme, error) {
+	data, err := embeddedThemes.ReadFile("themes/" + DefaultName + ".toml")
+	if err != nil {
+		return Theme{}, fmt.Errorf("could not read default theme: %v", err)
+	}
+	var t Theme
+	if err := toml.Unmarshal(data, &t); err != nil {
+		return Theme{}, fmt.Errorf("could not parse default theme: %v", err)
+	}
+	t.Name = DefaultName
+	return t, nil
+}
+
+// parse decodes a theme file, filling unset colors from the default theme
+func parse(name, ext string, data []byte) (Theme, error) {
+	var t Theme
+	if name != DefaultName {
+		base, err := Load(DefaultName)
+		if err != nil {
+			return Theme{}, err
+		}
+		t = base
+	}
+
+	var err error
+	if ext == ".json" {
+		err = json.Unmarshal(data, &t)
+	} else {
+		err = toml.Unmarshal(data, &t)
+	}
+	if err != nil {
+		return Theme{}, fmt.Errorf("could not parse theme '%s': %v", name, err)
+	}
+
+	t.Name = name
+	return t, nil
+}
+
+// Names returns the sorted names of all embedded and user themes
+func Names() ([]string, error) {
+	seen := make(map[string]bool)
+
+	entries, err := fs.ReadDir(embeddedThemes, "themes")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		seen[strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))] = true
+	}
+
+	if dir, err := UserDir(); err == nil {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("could not read themes directory: %v", err)
+		}
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); ext == ".toml" || ext == ".json" {
+				seen[strings.TrimSuffix(entry.Name(), ext)] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
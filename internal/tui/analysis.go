@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const analysisColumnGap = 6
+
+// RenderAnalysis draws the confusion matrix and n-gram rankings as columns
+func RenderAnalysis(a game.Analysis) string {
+	if a.KeystrokesTotal == 0 {
+		return mutedStyle.Render("no keystrokes recorded yet")
+	}
+
+	var mistyped []string
+	for _, c := range a.Confusions {
+		mistyped = append(mistyped, fmt.Sprintf("%s → %s  %s",
+			boldStyle.Render(visibleText(c.Expected)),
+			errorStyle.Render(visibleText(c.Typed)),
+			mutedStyle.Render(fmt.Sprintf("×%d", c.Count)),
+		))
+	}
+
+	topRow := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		analysisColumn("mistyped", mistyped),
+		strings.Repeat(" ", analysisColumnGap),
+		analysisColumn("slow bigrams", latencyLines(a.SlowestBigrams)),
+		strings.Repeat(" ", analysisColumnGap),
+		analysisColumn("error bigrams", errorLines(a.ErrorBigrams)),
+	)
+
+	bottomRow := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		analysisColumn("slow trigrams", latencyLines(a.SlowestTrigrams)),
+		strings.Repeat(" ", analysisColumnGap),
+		analysisColumn("error trigrams", errorLines(a.ErrorTrigrams)),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, topRow, spacer, bottomRow)
+}
+
+// analysisColumn renders a titled list, or a placeholder when it is empty
+func analysisColumn(title string, lines []string) string {
+	if len(lines) == 0 {
+		lines = []string{mutedStyle.Render("-")}
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{mutedStyle.Render(title)}, lines...)...)
+}
+
+// latencyLines formats n-grams with their average transition time
+func latencyLines(stats []game.NgramStat) []string {
+	var lines []string
+	for _, s := range stats {
+		lines = append(lines, fmt.Sprintf("%s %s",
+			boldStyle.Render(fmt.Sprintf("%-4s", visibleText(s.Ngram))),
+			mutedStyle.Render(s.AvgLatency.Round(time.Millisecond).String()),
+		))
+	}
+	return lines
+}
+
+// errorLines formats n-grams with their error rate
+func errorLines(stats []game.NgramStat) []string {
+	var lines []string
+	for _, s := range stats {
+		lines = append(lines, fmt.Sprintf("%s %s",
+			boldStyle.Render(fmt.Sprintf("%-4s", visibleText(s.Ngram))),
+			mutedStyle.Render(fmt.Sprintf("%.0f%% of %d", s.ErrorRate*100, s.Occurrences)),
+		))
+	}
+	return lines
+}
+
+// visibleText replaces spaces and newlines with visible symbols
+func visibleText(s string) string {
+	return strings.NewReplacer(" ", "␣", "\n", "⏎", "\t", "⇥").Replace(s)
+}
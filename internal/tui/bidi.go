@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// bidiClass is a simplified Unicode bidirectional character type
+type bidiClass int
+
+const (
+	bidiNeutral bidiClass = iota // Spaces and punctuation
+	bidiLeft                     // Strong left-to-right letters
+	bidiRight                    // Strong right-to-left letters
+	bidiNumber                   // Digits, which always read left to right
+)
+
+// rtlScripts are the scripts whose letters are strongly right-to-left
+var rtlScripts = []*unicode.RangeTable{
+	unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko,
+}
+
+// classify returns the bidi class of a grapheme cluster from its base rune
+func classify(cluster string) bidiClass {
+	r, _ := utf8.DecodeRuneInString(cluster)
+	switch {
+	case unicode.IsDigit(r):
+		return bidiNumber
+	case unicode.In(r, rtlScripts...):
+		return bidiRight
+	case unicode.IsLetter(r):
+		return bidiLeft
+	}
+	return bidiNeutral
+}
+
+// visualOrder returns the logical indices of clusters in display order from left
+// to right. It resolves embedding levels for a single line, a trimmed-down form of
+// the Unicode Bidirectional Algorithm: runs of the opposite direction and numbers
+// are embedded one level deeper, neutrals between two runs of the same level join
+// them, and the line is reordered by reversing runs from the deepest level up.
+func visualOrder(clusters []string, rtl bool) []int {
+	base := 0
+	if rtl {
+		base = 1
+	}
+
+	levels := make([]int, len(clusters))
+	lastStrong := bidiLeft
+	if rtl {
+		lastStrong = bidiRight
+	}
+	for i, c := range clusters {
+		switch classify(c) {
+		case bidiLeft:
+			levels[i] = 2 * base // 0 in LTR lines, 2 in RTL lines
+			lastStrong = bidiLeft
+		case bidiRight:
+			levels[i] = 1
+			lastStrong = bidiRight
+		case bidiNumber:
+			// Numbers keep their digit order but follow the surrounding run
+			if rtl || lastStrong == bidiRight {
+				levels[i] = 2
+			} else {
+				levels[i] = 0
+			}
+		default:
+			levels[i] = -1
+		}
+	}
+
+	// Neutrals take the level of the runs around them when both sides agree
+	for i := 0; i < len(levels); i++ {
+		if levels[i] != -1 {
+			continue
+		}
+		j := i
+		for j < len(levels) && levels[j] == -1 {
+			j++
+		}
+		level := base
+		if i > 0 && j < len(levels) && levels[i-1] == levels[j] {
+			level = levels[j]
+		}
+		for k := i; k < j; k++ {
+			levels[k] = level
+		}
+		i = j - 1
+	}
+
+	order := make([]int, len(clusters))
+	maxLevel := 0
+	for i := range order {
+		order[i] = i
+		maxLevel = max(maxLevel, levels[i])
+	}
+
+	// Reverse every run at or above each level, deepest first
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < len(order); {
+			if levels[order[i]] < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(order) && levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
@@ -0,0 +1,250 @@
+package tui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/emacs"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EmacsOptions configures the Emacs chord trainer
+type EmacsOptions struct {
+	Prompts func() []emacs.Command // Draws a fresh set of prompts for each round
+	Stats   *emacs.Stats           // Per-command stats kept across sessions
+}
+
+// chordAttempt is the outcome of the last prompt
+type chordAttempt struct {
+	command emacs.Command
+	pressed []string // Keys pressed, as Bubble Tea names them
+	correct bool
+	elapsed time.Duration
+}
+
+// ChordTrainer prompts for Emacs commands and checks the key sequence pressed for each
+type ChordTrainer struct {
+	opts     EmacsOptions
+	width    int
+	height   int
+	prompts  []emacs.Command
+	index    int
+	pressed  []string
+	shown    time.Time // When the current prompt appeared
+	last     *chordAttempt
+	session  map[string]emacs.CommandStats
+	order    []string // Commands in the order first practiced this round
+	finished bool
+	saveErr  error
+}
+
+// NewEmacs creates the trainer with a first round of prompts
+func NewEmacs(opts EmacsOptions) *ChordTrainer {
+	c := &ChordTrainer{opts: opts}
+	c.newRound()
+	return c
+}
+
+// newRound draws new prompts and shows the first one
+func (c *ChordTrainer) newRound() {
+	c.prompts = c.opts.Prompts()
+	c.index = 0
+	c.pressed = nil
+	c.last = nil
+	c.session = make(map[string]emacs.CommandStats)
+	c.order = nil
+	c.finished = false
+	c.saveErr = nil
+	c.shown = time.Now()
+}
+
+// Init implements tea.Model
+func (c ChordTrainer) Init() tea.Cmd {
+	return nil
+}
+
+// Update checks each key against the binding of the prompted command
+func (c ChordTrainer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.width, c.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if c.finished {
+			switch msg.String() {
+			case "ctrl+c", "esc", "q":
+				return c, tea.Quit
+			case "enter", "r":
+				c.newRound()
+			}
+			return c, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			c.finish()
+			return c, tea.Quit
+		case "esc":
+			c.finish()
+			return c, nil
+		}
+		if !msg.Paste {
+			c.press(msg.String())
+		}
+	}
+	return c, nil
+}
+
+// press handles one key of a chord sequence. A wrong key ends the attempt
+// at once, like an unbound key in Emacs.
+func (c *ChordTrainer) press(key string) {
+	command := c.prompts[c.index]
+	want := command.Sequence()
+
+	c.pressed = append(c.pressed, key)
+	correct := key == want[len(c.pressed)-1]
+	if correct && len(c.pressed) < len(want) {
+		return
+	}
+
+	attempt := chordAttempt{command: command, pressed: c.pressed, correct: correct, elapsed: time.Since(c.shown)}
+	c.last = &attempt
+	stats := c.session[command.Name]
+	stats.Add(correct, attempt.elapsed)
+	c.session[command.Name] = stats
+	if !slices.Contains(c.order, command.Name) {
+		c.order = append(c.order, command.Name)
+	}
+	if c.opts.Stats != nil {
+		c.opts.Stats.Record(command.Name, correct, attempt.elapsed)
+	}
+
+	c.index++
+	c.pressed = nil
+	c.shown = time.Now()
+	if c.index >= len(c.prompts) {
+		c.finish()
+	}
+}
+
+// finish ends the round and saves the stats
+func (c *ChordTrainer) finish() {
+	if c.finished {
+		return
+	}
+	c.finished = true
+	if c.opts.Stats != nil && len(c.order) > 0 {
+		c.saveErr = c.opts.Stats.Save()
+	}
+}
+
+// View renders the current prompt or the round summary
+func (c ChordTrainer) View() string {
+	var content string
+	if c.finished {
+		content = c.renderSummary()
+	} else {
+		content = c.renderPrompt()
+	}
+	return lipgloss.Place(
+		c.width, c.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// renderPrompt shows the command to run, the keys pressed so far and the last outcome
+func (c ChordTrainer) renderPrompt() string {
+	command := c.prompts[c.index]
+
+	pressed := mutedStyle.Render("…")
+	if len(c.pressed) > 0 {
+		pressed = boldStyle.Render(describeKeys(c.pressed) + " …")
+	}
+
+	rows := []string{
+		timeStyle.MarginLeft(0).Render(fmt.Sprintf("emacs %d/%d", c.index+1, len(c.prompts))),
+		spacer,
+		boldStyle.Render(command.Name),
+		pressed,
+		spacer,
+	}
+
+	if last := c.last; last != nil {
+		if last.correct {
+			rows = append(rows, resultValueStyle.Render(fmt.Sprintf("✓ %s  %s  %.2fs",
+				last.command.Name, last.command.Keys, last.elapsed.Seconds())))
+		} else {
+			rows = append(rows, errorStyle.Render(fmt.Sprintf("✗ pressed %s, %s is %s",
+				describeKeys(last.pressed), last.command.Name, last.command.Keys)))
+		}
+	} else {
+		rows = append(rows, mutedStyle.Render("press the keys bound to the command"))
+	}
+
+	rows = append(rows, spacer, mutedStyle.Render("esc finish • ctrl+c quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderSummary lists the accuracy and speed of every command practiced this round
+func (c ChordTrainer) renderSummary() string {
+	rows := []string{timeStyle.MarginLeft(0).Render("emacs results"), spacer}
+
+	if len(c.order) == 0 {
+		rows = append(rows, mutedStyle.Render("no commands attempted"))
+	}
+
+	// Weakest commands first
+	order := slices.Clone(c.order)
+	slices.SortStableFunc(order, func(a, b string) int {
+		return cmp.Compare(c.session[a].Accuracy(), c.session[b].Accuracy())
+	})
+
+	var total emacs.CommandStats
+	for _, name := range order {
+		s := c.session[name]
+		total.Attempts += s.Attempts
+		total.Correct += s.Correct
+		total.TotalMs += s.TotalMs
+
+		keys := ""
+		if i := slices.IndexFunc(c.prompts, func(p emacs.Command) bool { return p.Name == name }); i >= 0 {
+			keys = c.prompts[i].Keys
+		}
+
+		line := resultLabelStyle.Render(fmt.Sprintf("%-26s", name)) +
+			mutedStyle.Render(fmt.Sprintf("%-9s", keys)) +
+			resultValueStyle.Render(fmt.Sprintf("%4.0f%% %2d/%-2d", s.Accuracy(), s.Correct, s.Attempts))
+		if s.Correct > 0 {
+			line += resultValueStyle.Render(fmt.Sprintf("  %.2fs", s.AverageTime().Seconds()))
+		}
+		if c.opts.Stats != nil {
+			line += mutedStyle.Render(fmt.Sprintf("  all-time %.0f%%", c.opts.Stats.Commands[name].Accuracy()))
+		}
+		rows = append(rows, line)
+	}
+
+	if total.Attempts > 0 {
+		rows = append(rows, spacer,
+			resultLabelStyle.Render("accuracy ")+resultValueStyle.Render(fmt.Sprintf("%.0f%%", total.Accuracy()))+
+				resultLabelStyle.Render("  average ")+resultValueStyle.Render(fmt.Sprintf("%.2fs", total.AverageTime().Seconds())))
+	}
+	if c.saveErr != nil {
+		rows = append(rows, spacer, errorStyle.Render("stats not saved: "+c.saveErr.Error()))
+	}
+	rows = append(rows, spacer, mutedStyle.Render("enter new round • esc quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// describeKeys renders Bubble Tea key names in Emacs notation, e.g. "C-x C-s"
+func describeKeys(keys []string) string {
+	described := make([]string, len(keys))
+	for i, k := range keys {
+		described[i] = emacs.Describe(k)
+	}
+	return strings.Join(described, " ")
+}
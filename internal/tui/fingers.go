@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fingerBarWidth is the width of the load bar at 100%
+const fingerBarWidth = 20
+
+// RenderFingerUsage draws per-finger load and error bars followed by hand balance,
+// alternation and same-finger bigram figures
+func RenderFingerUsage(u game.FingerUsage) string {
+	// Find the busiest finger so bars use the full width
+	var maxLoad float64
+	for _, f := range u.Fingers {
+		maxLoad = max(maxLoad, f.Load)
+	}
+	if maxLoad == 0 {
+		return mutedStyle.Render("no keystrokes recorded yet")
+	}
+
+	rows := []string{mutedStyle.Render(fmt.Sprintf("%-13s %-*s %6s %7s", "finger", fingerBarWidth, "load", "keys", "errors"))}
+	for _, f := range u.Fingers {
+		filled := int(f.Load/maxLoad*fingerBarWidth + 0.5)
+		bar := boldStyle.Render(strings.Repeat("█", filled)) +
+			mutedStyle.Render(strings.Repeat("░", fingerBarWidth-filled))
+
+		errors := mutedStyle.Render(fmt.Sprintf("%6.0f%%", f.ErrorRate*100))
+		if f.Errors > 0 {
+			errors = errorStyle.Render(fmt.Sprintf("%6.0f%%", f.ErrorRate*100))
+		}
+
+		rows = append(rows, fmt.Sprintf("%s %s %s %s",
+			resultLabelStyle.Render(fmt.Sprintf("%-13s", f.Finger)),
+			bar,
+			resultValueStyle.Render(fmt.Sprintf("%6d", f.Presses)),
+			errors,
+		))
+	}
+
+	summary := []string{
+		mutedStyle.Render("left ") + percent(u.LeftHand) + mutedStyle.Render("  right ") + percent(u.RightHand),
+		mutedStyle.Render("alternation ") + percent(u.AlternationRate),
+		mutedStyle.Render("same finger ") + percent(u.SameFingerRate),
+	}
+	rows = append(rows, spacer, strings.Join(summary, mutedStyle.Render("  •  ")))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// percent formats a fraction as a bold percentage
+func percent(f float64) string {
+	return boldStyle.Render(fmt.Sprintf("%.0f%%", f*100))
+}
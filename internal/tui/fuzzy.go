@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+)
+
+// fuzzyScore matches query as a case-insensitive subsequence of target.
+// Higher scores mean better matches; consecutive runs and matches at the
+// start of the target are favored.
+func fuzzyScore(query, target string) (int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(target))
+	if len(q) == 0 {
+		return 0, true
+	}
+
+	score, qi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && qi < len(q); ti++ {
+		if t[ti] != q[qi] {
+			run = 0
+			continue
+		}
+		run++
+		score += 1 + run*2
+		if ti == 0 {
+			score += 5
+		}
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+
+	// Prefer shorter targets when the match is otherwise equal
+	return score*100 - len(t), true
+}
+
+// fuzzyFilter returns the targets matching query, best match first
+func fuzzyFilter(query string, targets []string) []string {
+	type match struct {
+		target string
+		score  int
+	}
+
+	var matches []match
+	for _, target := range targets {
+		if score, ok := fuzzyScore(query, target); ok {
+			matches = append(matches, match{target, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	result := make([]string, len(matches))
+	for i, m := range matches {
+		result[i] = m.target
+	}
+	return result
+}
@@ -0,0 +1,162 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// HeatmapMetric selects which statistic colors the keyboard heatmap
+type HeatmapMetric int
+
+const (
+	HeatmapErrors HeatmapMetric = iota
+	HeatmapLatency
+)
+
+// String returns the display name of the metric
+func (h HeatmapMetric) String() string {
+	if h == HeatmapLatency {
+		return "latency"
+	}
+	return "errors"
+}
+
+// ParseHeatmapMetric converts a metric name into a HeatmapMetric
+func ParseHeatmapMetric(name string) (HeatmapMetric, error) {
+	switch strings.ToLower(name) {
+	case "errors", "error":
+		return HeatmapErrors, nil
+	case "latency":
+		return HeatmapLatency, nil
+	}
+	return HeatmapErrors, fmt.Errorf("unknown heatmap metric '%s' (available: errors, latency)", name)
+}
+
+// heatPalette runs from cool (good) to hot (bad). Colors are filled in by ApplyTheme.
+var heatPalette []lipgloss.AdaptiveColor
+
+// Row offsets approximate the stagger of a physical keyboard
+var heatmapRowOffsets = []int{0, 2, 3, 4}
+
+const heatmapSpaceOffset = 14
+const heatmapSpaceWidth = 23
+
+// RenderHeatmap draws an on-screen keyboard with each key colored by the chosen metric
+func RenderHeatmap(stats map[rune]game.KeyStat, layout keyboard.Layout, metric HeatmapMetric) string {
+	// Fold shifted characters onto the key that produces them
+	keys := make(map[rune]game.KeyStat)
+	for char, stat := range stats {
+		if key, ok := layout.KeyFor(char); ok {
+			merged := keys[key]
+			merged.Add(stat)
+			keys[key] = merged
+		}
+	}
+
+	value := func(s game.KeyStat) (float64, bool) {
+		if metric == HeatmapLatency {
+			if s.TimedPresses == 0 {
+				return 0, false
+			}
+			return float64(s.AvgLatency()), true
+		}
+		if s.Presses == 0 {
+			return 0, false
+		}
+		return s.ErrorRate(), true
+	}
+
+	// Find the range so colors are relative to this data set
+	minVal, maxVal := 0.0, 0.0
+	first := true
+	for _, stat := range keys {
+		v, ok := value(stat)
+		if !ok {
+			continue
+		}
+		if first || v < minVal {
+			minVal = v
+		}
+		if first || v > maxVal {
+			maxVal = v
+		}
+		first = false
+	}
+	if metric == HeatmapErrors {
+		minVal = 0
+	}
+
+	renderKey := func(key rune, label string) string {
+		stat, exists := keys[key]
+		v, ok := value(stat)
+		if !exists || !ok {
+			return mutedStyle.Render(label)
+		}
+		level := 0
+		if maxVal > minVal {
+			level = int((v - minVal) / (maxVal - minVal) * float64(len(heatPalette)-1))
+		}
+		return heatKeyStyle.Background(heatPalette[level]).Render(label)
+	}
+
+	var rows []string
+	for i, row := range layout.Rows {
+		var line strings.Builder
+		line.WriteString(strings.Repeat(" ", heatmapRowOffsets[i%len(heatmapRowOffsets)]))
+		for j, key := range []rune(row) {
+			if j > 0 {
+				line.WriteString(" ")
+			}
+			line.WriteString(renderKey(key, " "+string(key)+" "))
+		}
+		rows = append(rows, line.String())
+	}
+
+	space := centerLabel("space", heatmapSpaceWidth)
+	rows = append(rows, strings.Repeat(" ", heatmapSpaceOffset)+renderKey(' ', space))
+
+	rows = append(rows, spacer, renderHeatLegend(metric, minVal, maxVal, !first))
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderHeatLegend explains the color scale below the keyboard
+func renderHeatLegend(metric HeatmapMetric, minVal, maxVal float64, hasData bool) string {
+	if !hasData {
+		return mutedStyle.Render(metric.String() + ": no data yet")
+	}
+
+	format := func(v float64) string {
+		if metric == HeatmapLatency {
+			return time.Duration(v).Round(time.Millisecond).String()
+		}
+		return fmt.Sprintf("%.0f%%", v*100)
+	}
+
+	var scale strings.Builder
+	for _, color := range heatPalette {
+		scale.WriteString(lipgloss.NewStyle().Foreground(color).Render("█"))
+	}
+
+	return fmt.Sprintf("%s  %s %s %s",
+		mutedStyle.Render(metric.String()),
+		mutedStyle.Render(format(minVal)),
+		scale.String(),
+		mutedStyle.Render(format(maxVal)),
+	)
+}
+
+// centerLabel pads label with spaces to the given width
+func centerLabel(label string, width int) string {
+	pad := width - len(label)
+	if pad <= 0 {
+		return label
+	}
+	return strings.Repeat(" ", pad/2) + label + strings.Repeat(" ", pad-pad/2)
+}
@@ -0,0 +1,294 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/ashish0kumar/typtea/internal/keymap"
+	"github.com/ashish0kumar/typtea/internal/learn"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LearnOptions configures the lesson course
+type LearnOptions struct {
+	Test       Options // Settings for each drill
+	Lessons    []learn.Lesson
+	Progress   *learn.Progress
+	Dictionary []string // Real words drills draw from
+}
+
+// learnLanguage is the language drills are labeled and rendered with
+const learnLanguage = "en"
+
+// learnScreen identifies a screen of the course
+type learnScreen int
+
+const (
+	screenLessons learnScreen = iota
+	screenDrill
+	screenVerdict
+)
+
+// lessonVerdict is the outcome of the last drill
+type lessonVerdict struct {
+	passed   bool
+	wpm      float64
+	accuracy float64
+	unlocked string // Title of the lesson this attempt unlocked, if any
+	saveErr  error
+}
+
+// Learn is the lesson selection screen and the drills launched from it
+type Learn struct {
+	opts    LearnOptions
+	width   int
+	height  int
+	cursor  int
+	screen  learnScreen
+	drill   Model
+	verdict lessonVerdict
+	status  string
+}
+
+// NewLearn starts the course on the first lesson not yet passed
+func NewLearn(opts LearnOptions) *Learn {
+	l := &Learn{opts: opts}
+	for i, lesson := range opts.Lessons {
+		l.cursor = i
+		if !opts.Progress.Lessons[lesson.ID].Passed {
+			break
+		}
+	}
+	return l
+}
+
+// Init implements tea.Model
+func (l Learn) Init() tea.Cmd {
+	return nil
+}
+
+// Update routes messages to the current screen
+func (l Learn) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		l.width, l.height = size.Width, size.Height
+	}
+
+	switch l.screen {
+	case screenDrill:
+		return l.updateDrill(msg)
+	case screenVerdict:
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return l.updateVerdict(key)
+		}
+	default:
+		if key, ok := msg.(tea.KeyMsg); ok {
+			return l.updateLessons(key)
+		}
+	}
+	return l, nil
+}
+
+// updateLessons handles navigation on the lesson list
+func (l Learn) updateLessons(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	l.status = ""
+	switch key.String() {
+	case "ctrl+c", "esc", "q":
+		return l, tea.Quit
+	case "up", "k":
+		l.cursor = max(0, l.cursor-1)
+	case "down", "j":
+		l.cursor = min(len(l.opts.Lessons)-1, l.cursor+1)
+	case "enter", " ":
+		return l.startDrill()
+	}
+	return l, nil
+}
+
+// updateVerdict handles keys on the screen shown after a drill
+func (l Learn) updateVerdict(key tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key.String() {
+	case "ctrl+c":
+		return l, tea.Quit
+	case "esc":
+		l.screen = screenLessons
+	case "r":
+		return l.startDrill()
+	case "enter":
+		// Move on after a pass, otherwise try again
+		if l.verdict.passed && l.cursor+1 < len(l.opts.Lessons) {
+			l.cursor++
+		}
+		return l.startDrill()
+	}
+	return l, nil
+}
+
+// updateDrill forwards messages to the running drill and scores it once it ends
+func (l Learn) updateDrill(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Quitting a drill returns to the lesson list rather than exiting
+	if key, ok := msg.(tea.KeyMsg); ok && !l.drill.showHelp {
+		if action, ok := l.drill.keys.Action(key.String(), keymap.ContextTest); ok && action == keymap.Quit {
+			l.screen = screenLessons
+			return l, nil
+		}
+	}
+
+	updated, cmd := l.drill.Update(msg)
+	l.drill = updated.(Model)
+	if l.drill.showResults {
+		l.finishDrill()
+		return l, nil
+	}
+	return l, cmd
+}
+
+// startDrill launches a drill for the selected lesson if it is unlocked
+func (l Learn) startDrill() (tea.Model, tea.Cmd) {
+	lessons := l.opts.Lessons
+	if !l.opts.Progress.Unlocked(lessons, l.cursor) {
+		l.screen = screenLessons
+		l.status = "pass " + lessons[l.cursor-1].Title + " to unlock " + lessons[l.cursor].Title
+		return l, nil
+	}
+
+	opts := l.opts.Test
+	opts.Language = learnLanguage
+	opts.Source = lessons[l.cursor].Source(l.opts.Dictionary)
+	drill, err := NewModel(opts)
+	if err != nil {
+		l.status = err.Error()
+		return l, nil
+	}
+
+	// Drills are tracked as lesson progress rather than in the history
+	drill.store = nil
+	drill.saveErr = nil
+	drill.width, drill.height = l.width, l.height
+
+	l.drill = *drill
+	l.screen = screenDrill
+	return l, drill.Init()
+}
+
+// finishDrill scores the finished drill, records progress and shows the verdict
+func (l *Learn) finishDrill() {
+	lessons := l.opts.Lessons
+	lesson := lessons[l.cursor]
+	stats := l.drill.finalStats
+
+	wasUnlocked := l.cursor+1 < len(lessons) && l.opts.Progress.Unlocked(lessons, l.cursor+1)
+	l.verdict = lessonVerdict{
+		passed:   l.opts.Progress.Record(lesson, stats.WPM, stats.Accuracy),
+		wpm:      stats.WPM,
+		accuracy: stats.Accuracy,
+		saveErr:  l.opts.Progress.Save(),
+	}
+	if l.verdict.passed && l.cursor+1 < len(lessons) && !wasUnlocked {
+		l.verdict.unlocked = lessons[l.cursor+1].Title
+	}
+	l.screen = screenVerdict
+}
+
+// View renders the current screen
+func (l Learn) View() string {
+	var content string
+	switch l.screen {
+	case screenDrill:
+		return l.drill.View()
+	case screenVerdict:
+		content = l.renderVerdict()
+	default:
+		content = l.renderLessons()
+	}
+
+	return lipgloss.Place(
+		l.width, l.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// renderLessons lists every lesson with its keys, best result and lock state
+func (l Learn) renderLessons() string {
+	rows := []string{timeStyle.MarginLeft(0).Render("learn"), spacer}
+
+	for i, lesson := range l.opts.Lessons {
+		result := l.opts.Progress.Lessons[lesson.ID]
+		title := fmt.Sprintf("%d. %-14s", i+1, lesson.Title)
+
+		var state string
+		switch {
+		case result.Passed:
+			state = boldStyle.Render("✓ ") + mutedStyle.Render(fmt.Sprintf("best %.0f wpm %.0f%%", result.BestWPM, result.BestAccuracy))
+		case !l.opts.Progress.Unlocked(l.opts.Lessons, i):
+			state = mutedStyle.Render("locked")
+		case result.Attempts > 0:
+			state = mutedStyle.Render(fmt.Sprintf("best %.0f wpm %.0f%%", result.BestWPM, result.BestAccuracy))
+		default:
+			state = mutedStyle.Render("new")
+		}
+
+		keys := mutedStyle.Render(fmt.Sprintf("%-18s", visibleText(lesson.Keys)))
+		if i == l.cursor {
+			rows = append(rows, boldStyle.Render("▸ "+title)+keys+"  "+state)
+		} else {
+			rows = append(rows, "  "+resultLabelStyle.Render(title)+keys+"  "+state)
+		}
+	}
+
+	if l.status != "" {
+		rows = append(rows, spacer, errorStyle.Render(l.status))
+	}
+
+	lesson := l.opts.Lessons[l.cursor]
+	rows = append(rows,
+		spacer,
+		mutedStyle.Render(fmt.Sprintf("pass: %.0f wpm at %.0f%% accuracy", lesson.MinWPM, lesson.MinAccuracy)),
+		mutedStyle.Render("enter start • ↑/↓ select • esc quit"),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderVerdict shows the drill result against the lesson's pass criteria
+func (l Learn) renderVerdict() string {
+	lesson := l.opts.Lessons[l.cursor]
+	v := l.verdict
+
+	headline := errorStyle.Render("not yet — keep practicing")
+	if v.passed {
+		headline = boldStyle.Render("lesson passed")
+	}
+
+	criterion := func(label string, value, target float64, unit string) string {
+		style := errorStyle
+		if value >= target {
+			style = resultValueStyle
+		}
+		return resultLabelStyle.Render(fmt.Sprintf("%-5s", label)) +
+			style.Render(fmt.Sprintf("%4.0f%s", value, unit)) +
+			mutedStyle.Render(fmt.Sprintf("  need %.0f%s", target, unit))
+	}
+
+	rows := []string{
+		timeStyle.MarginLeft(0).Render(lesson.Title),
+		spacer,
+		headline,
+		spacer,
+		criterion("wpm", v.wpm, lesson.MinWPM, ""),
+		criterion("acc", v.accuracy, lesson.MinAccuracy, "%"),
+	}
+	if v.unlocked != "" {
+		rows = append(rows, spacer, boldStyle.Render("unlocked: "+v.unlocked))
+	}
+	if v.saveErr != nil {
+		rows = append(rows, spacer, errorStyle.Render("progress not saved: "+v.saveErr.Error()))
+	}
+
+	next := "enter retry"
+	if v.passed && l.cursor+1 < len(l.opts.Lessons) {
+		next = "enter next lesson • r retry"
+	}
+	rows = append(rows, spacer, mutedStyle.Render(next+" • esc lessons"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -0,0 +1,369 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/theme"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// menuDurations are the durations offered on the home screen, in seconds
+var menuDurations = []int{15, 30, 60, 120}
+
+// menuListHeight is the number of languages visible at once
+const menuListHeight = 10
+
+// MenuOptions configures the home screen
+type MenuOptions struct {
+	Test       Options       // Defaults for the test launched from the menu
+	Languages  []string      // All selectable languages
+	Recent     []string      // Recently used languages, most recent first
+	Config     config.Config // Config file contents edited by the settings page
+	ConfigPath string
+}
+
+// menuSetting is an editable row on the settings page
+type menuSetting struct {
+	key     string
+	options []string
+}
+
+// Menu is the home screen for picking a mode, duration and language
+type Menu struct {
+	opts      MenuOptions
+	width     int
+	height    int
+	query     string
+	cursor    int
+	durations []int
+	duration  int // Index into durations
+	mode      int // Index into config.Modes
+
+	settings       []menuSetting
+	showSettings   bool
+	settingsCursor int
+	settingsDirty  bool // Whether a setting changed since the page was opened
+	status         string
+}
+
+// NewMenu initializes the home screen with the defaults in opts
+func NewMenu(opts MenuOptions) *Menu {
+	durations := slices.Clone(menuDurations)
+	if !slices.Contains(durations, opts.Test.Duration) {
+		durations = append(durations, opts.Test.Duration)
+		slices.Sort(durations)
+	}
+
+	themes, _ := theme.Names()
+	bools := []string{"false", "true"}
+
+	// Keep a custom layout file reachable while cycling through the built-in ones
+	layouts := keyboard.Names()
+	if !slices.Contains(layouts, opts.Config.Layout) {
+		layouts = append(layouts, opts.Config.Layout)
+	}
+
+	m := &Menu{
+		opts:      opts,
+		durations: durations,
+		duration:  slices.Index(durations, opts.Test.Duration),
+		mode:      max(0, slices.Index(config.Modes, opts.Test.Mode)),
+		settings: []menuSetting{
+			{"caret", config.CaretStyles},
+			{"live_stats", bools},
+			{"backspace", config.BackspacePolicy},
+			{"theme", themes},
+			{"confirm_quit", bools},
+			{"ascii_fold", bools},
+			{"highlight", bools},
+			{"indent", config.IndentPolicies},
+			{"difficulty", game.Difficulties},
+			{"visibility", config.Visibilities},
+			{"layout", layouts},
+		},
+	}
+
+	// Start on the configured language
+	if i := slices.Index(m.filtered(), opts.Test.Language); i >= 0 {
+		m.cursor = i
+	}
+	return m
+}
+
+// Init implements tea.Model
+func (m Menu) Init() tea.Cmd {
+	return nil
+}
+
+// filtered returns the languages matching the search, with recent ones first when not searching
+func (m Menu) filtered() []string {
+	if m.query != "" {
+		return fuzzyFilter(m.query, m.opts.Languages)
+	}
+
+	var list []string
+	for _, lang := range m.opts.Recent {
+		if slices.Contains(m.opts.Languages, lang) && !slices.Contains(list, lang) {
+			list = append(list, lang)
+		}
+	}
+	for _, lang := range m.opts.Languages {
+		if !slices.Contains(list, lang) {
+			list = append(list, lang)
+		}
+	}
+	return list
+}
+
+// Update handles navigation, search and launching a test
+func (m Menu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.showSettings {
+			return m.updateSettings(msg)
+		}
+
+		switch msg.String() {
+		case "esc":
+			if m.query != "" {
+				m.query = ""
+				m.cursor = 0
+				return m, nil
+			}
+			return m, tea.Quit
+
+		case "enter":
+			return m.launch()
+
+		case "up", "ctrl+p":
+			m.cursor = max(0, m.cursor-1)
+		case "down", "ctrl+n":
+			m.cursor = max(0, min(len(m.filtered())-1, m.cursor+1))
+		case "left":
+			m.duration = (m.duration + len(m.durations) - 1) % len(m.durations)
+		case "right":
+			m.duration = (m.duration + 1) % len(m.durations)
+		case "ctrl+t":
+			m.mode = (m.mode + 1) % len(config.Modes)
+		case "ctrl+s":
+			m.showSettings = true
+			m.status = ""
+
+		case "backspace":
+			if runes := []rune(m.query); len(runes) > 0 {
+				m.query = string(runes[:len(runes)-1])
+				m.cursor = 0
+			}
+
+		default:
+			if runes := []rune(msg.String()); msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				m.query += string(runes)
+				m.cursor = 0
+			}
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// updateSettings handles keys on the settings page
+func (m Menu) updateSettings(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+s":
+		m.showSettings = false
+		if !m.settingsDirty {
+			break
+		}
+		if err := config.Save(m.opts.ConfigPath, m.opts.Config); err != nil {
+			m.status = err.Error()
+		} else {
+			m.settingsDirty = false
+			m.status = "settings saved to " + m.opts.ConfigPath
+		}
+	case "up", "k":
+		m.settingsCursor = max(0, m.settingsCursor-1)
+	case "down", "j":
+		m.settingsCursor = min(len(m.settings)-1, m.settingsCursor+1)
+	case "left", "h":
+		m.cycleSetting(-1)
+	case "right", "l", "enter", " ":
+		m.cycleSetting(1)
+	}
+	return m, nil
+}
+
+// cycleSetting moves the selected setting to its next or previous option and applies it
+func (m *Menu) cycleSetting(step int) {
+	s := m.settings[m.settingsCursor]
+	if len(s.options) == 0 {
+		return
+	}
+
+	current, _ := m.opts.Config.Get(s.key)
+	i := (slices.Index(s.options, current) + step + len(s.options)) % len(s.options)
+	if err := m.opts.Config.Set(s.key, s.options[i]); err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.settingsDirty = true
+
+	// Keep the launch options in step with the edited config
+	cfg := m.opts.Config
+	switch s.key {
+	case "caret":
+		m.opts.Test.Caret, _ = ParseCaretStyle(cfg.Caret)
+	case "live_stats":
+		m.opts.Test.LiveStats = cfg.LiveStats
+	case "backspace":
+		m.opts.Test.Backspace, _ = game.ParseBackspacePolicy(cfg.Backspace)
+	case "confirm_quit":
+		m.opts.Test.ConfirmQuit = cfg.ConfirmQuit
+	case "layout":
+		if l, err := keyboard.Resolve(cfg.Layout); err == nil {
+			m.opts.Test.Layout = l
+			m.opts.Test.Keyboard = l
+		}
+	case "ascii_fold":
+		m.opts.Test.FoldASCII = cfg.ASCIIFold
+	case "highlight":
+		m.opts.Test.Highlight = cfg.Highlight
+	case "indent":
+		m.opts.Test.Indent, _ = game.ParseIndentPolicy(cfg.Indent)
+	case "difficulty":
+		m.opts.Test.Difficulty, _ = game.ParseDifficulty(cfg.Difficulty)
+	case "visibility":
+		m.opts.Test.Visibility, _ = ParseVisibility(cfg.Visibility)
+	case "theme":
+		if t, err := theme.Load(cfg.Theme); err == nil {
+			ApplyTheme(t)
+		} else {
+			m.status = err.Error()
+		}
+	}
+}
+
+// launch replaces the menu with a typing test for the selected entry
+func (m Menu) launch() (tea.Model, tea.Cmd) {
+	list := m.filtered()
+	if len(list) == 0 {
+		return m, nil
+	}
+
+	opts := m.opts.Test
+	opts.Language = list[m.cursor]
+	opts.Duration = m.durations[m.duration]
+	opts.Mode = config.Modes[m.mode]
+
+	model, err := NewModel(opts)
+	if err != nil {
+		m.status = err.Error()
+		return m, nil
+	}
+	model.width, model.height = m.width, m.height
+	return model, model.Init()
+}
+
+// View renders the home screen or the settings page
+func (m Menu) View() string {
+	var content string
+	if m.showSettings {
+		content = m.renderSettings()
+	} else {
+		content = m.renderHome()
+	}
+
+	if m.status != "" {
+		content = lipgloss.JoinVertical(lipgloss.Left, content, spacer, mutedStyle.Render(m.status))
+	}
+
+	return lipgloss.Place(
+		m.width, m.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// renderHome renders the mode, duration and language picker
+func (m Menu) renderHome() string {
+	field := func(label, value, hint string) string {
+		return resultLabelStyle.Render(fmt.Sprintf("%-10s", label)) +
+			resultValueStyle.Render(fmt.Sprintf("%-14s", value)) +
+			mutedStyle.Render(hint)
+	}
+
+	search := m.query
+	if search == "" {
+		search = mutedStyle.Render("type to search")
+	} else {
+		search = boldStyle.Render(search)
+	}
+
+	rows := []string{
+		timeStyle.MarginLeft(0).Render("typtea"),
+		spacer,
+		field("mode", config.Modes[m.mode], "ctrl+t"),
+		field("duration", fmt.Sprintf("‹ %ds ›", m.durations[m.duration]), "←/→"),
+		resultLabelStyle.Render(fmt.Sprintf("%-10s", "language")) + search + cursorStyle.Render(" "),
+		spacer,
+	}
+
+	list := m.filtered()
+	if len(list) == 0 {
+		rows = append(rows, mutedStyle.Render("  no matching languages"))
+	}
+
+	// Scroll the list so the cursor stays visible
+	start := max(0, min(m.cursor-menuListHeight/2, len(list)-menuListHeight))
+	end := min(len(list), start+menuListHeight)
+	for i := start; i < end; i++ {
+		label := list[i]
+		if m.query == "" && slices.Contains(m.opts.Recent, label) {
+			label += mutedStyle.Render("  recent")
+		}
+		if i == m.cursor {
+			rows = append(rows, boldStyle.Render("▸ ")+boldStyle.Render(label))
+		} else {
+			rows = append(rows, "  "+mutedStyle.Render(label))
+		}
+	}
+	for i := end - start; i < menuListHeight; i++ {
+		rows = append(rows, spacer)
+	}
+
+	rows = append(rows, spacer, mutedStyle.Render("enter start • ↑/↓ select • ctrl+s settings • esc quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderSettings renders the editable config settings
+func (m Menu) renderSettings() string {
+	rows := []string{timeStyle.MarginLeft(0).Render("settings"), spacer}
+
+	for i, s := range m.settings {
+		value, _ := m.opts.Config.Get(s.key)
+		label := resultLabelStyle.Render(fmt.Sprintf("%-14s", strings.ReplaceAll(s.key, "_", " ")))
+		if i == m.settingsCursor {
+			rows = append(rows, boldStyle.Render("▸ ")+label+boldStyle.Render("‹ "+value+" ›"))
+		} else {
+			rows = append(rows, "  "+label+resultValueStyle.Render("  "+value))
+		}
+	}
+
+	rows = append(rows, spacer, mutedStyle.Render("↑/↓ select • ←/→ change • esc save and go back"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
@@ -2,9 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"time"
 
 	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/history"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/keymap"
+	"github.com/ashish0kumar/typtea/internal/share"
+	"github.com/ashish0kumar/typtea/internal/syntax"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -18,31 +25,270 @@ type Model struct {
 	finalStats  game.TypingStats
 	duration    int
 	language    string
+	mode        string
+	keyboard    keyboard.Layout
+	caret       CaretStyle
+	liveStats   bool
+	backspace   game.BackspacePolicy
+	keys        keymap.Keymap
+	confirmQuit bool
+	foldASCII   bool
+	indent      game.IndentPolicy
+	tabWidth    int
+	difficulty  game.Difficulty
+	visibility  Visibility
+	funbox      []string // Text transforms, applied by the word source except mirror
+	rtl         bool
+	prompt      string              // Shown before each command line in shell mode
+	highlight   *syntax.Highlighter // Colors untyped code, nil for plain text
+	layout      keyboard.Layout
+	source      func(count int) []string
+	heatmap     HeatmapMetric
+	resultsPage resultsPage
+	store       *history.Store
+	saveErr     error
+	record      history.Record
+	status      string
+
+	showHelp       bool
+	confirmingQuit bool
 }
 
+// resultsPage identifies a page of the results screen
+type resultsPage int
+
+const (
+	pageOverview resultsPage = iota
+	pageAnalysis
+	pageFingers
+	resultsPageCount
+)
+
+// analysisLimit is the number of entries shown per list on the analysis page
+const analysisLimit = 5
+
 // tickMsg is a message type used to handle periodic updates in the application
 type tickMsg time.Time
 
-// NewModel initializes a new Model instance with the specified duration and language
-func NewModel(duration int, language string) (*Model, error) {
-	if err := game.SetLanguage(language); err != nil {
-		return nil, fmt.Errorf("failed to load language '%s': %v", language, err)
+// cardCopiedMsg reports whether the text card reached the clipboard
+type cardCopiedMsg struct {
+	err error
+}
+
+// Options configures a typing test session
+type Options struct {
+	Duration  int
+	Language  string
+	Mode      string
+	Keyboard  keyboard.Layout
+	Caret     CaretStyle
+	LiveStats bool
+	Backspace game.BackspacePolicy
+	Keys      keymap.Keymap
+	// ConfirmQuit requires the quit key to be pressed twice
+	ConfirmQuit bool
+	// FoldASCII accepts unaccented letters for accented ones
+	FoldASCII bool
+	// Highlight colors untyped text by syntax for programming languages
+	Highlight bool
+	// Indent sets how indentation of multi-line code is typed, with tabs
+	// standing for TabWidth spaces
+	Indent   game.IndentPolicy
+	TabWidth int
+	// Difficulty sets how strictly mistakes are punished
+	Difficulty game.Difficulty
+	// Visibility sets how much of the text is shown while typing
+	Visibility Visibility
+	// Funbox lists text transforms such as caps or leet, see game.Funboxes
+	Funbox []string
+	// Layout is emulated by remapping keys typed on a QWERTY keyboard
+	Layout keyboard.Layout
+	// Source generates the words to type instead of the language pack
+	Source func(count int) []string
+	// Filter narrows the language pack's words before they are drawn
+	Filter game.WordFilter
+}
+
+// CaretStyle selects how the current character is highlighted
+type CaretStyle int
+
+const (
+	CaretBlock CaretStyle = iota
+	CaretUnderline
+)
+
+// ParseCaretStyle converts a caret style name (block, underline) into a CaretStyle
+func ParseCaretStyle(name string) (CaretStyle, error) {
+	switch name {
+	case "", "block":
+		return CaretBlock, nil
+	case "underline":
+		return CaretUnderline, nil
 	}
+	return CaretBlock, fmt.Errorf("unknown caret style '%s' (available: block, underline)", name)
+}
+
+// NewModel initializes a new Model instance with the given options
+func NewModel(opts Options) (*Model, error) {
+	if err := game.SetLanguage(opts.Language); err != nil {
+		return nil, fmt.Errorf("failed to load language '%s': %v", opts.Language, err)
+	}
+	if err := game.SetWordFilter(opts.Filter); err != nil {
+		return nil, err
+	}
+
+	if opts.Keys.Empty() {
+		opts.Keys = keymap.Default()
+	}
+	if opts.Keyboard.Name == "" {
+		opts.Keyboard, _ = keyboard.Get(keyboard.Physical)
+	}
+	if opts.Mode == "" {
+		opts.Mode = history.ModeTime
+	}
+	if opts.Mode == history.ModeSymbols && opts.Source == nil {
+		opts.Source = game.SymbolSource()
+	}
+	if opts.Mode == history.ModeShell && opts.Source == nil {
+		source, err := game.ShellSource()
+		if err != nil {
+			return nil, err
+		}
+		opts.Source = source
+	}
+
+	if len(opts.Funbox) > 0 {
+		source := opts.Source
+		if source == nil {
+			source = game.GenerateWords
+		}
+		opts.Source = game.FunboxSource(source, opts.Funbox)
+	}
+
+	// History is optional; results are simply not saved if it can't be located
+	store, err := history.OpenDefault()
 
-	return &Model{
-		game:     game.NewTypingGame(duration),
-		duration: duration,
-		language: language,
-	}, nil
+	m := &Model{
+		duration:    opts.Duration,
+		language:    opts.Language,
+		mode:        opts.Mode,
+		keyboard:    opts.Keyboard,
+		caret:       opts.Caret,
+		liveStats:   opts.LiveStats,
+		backspace:   opts.Backspace,
+		keys:        opts.Keys,
+		confirmQuit: opts.ConfirmQuit,
+		foldASCII:   opts.FoldASCII,
+		indent:      opts.Indent,
+		tabWidth:    opts.TabWidth,
+		difficulty:  opts.Difficulty,
+		visibility:  opts.Visibility,
+		funbox:      opts.Funbox,
+		rtl:         game.IsRTL(),
+		prompt:      game.ShellPrompt(),
+		layout:      opts.Layout,
+		source:      opts.Source,
+		store:       store,
+		saveErr:     err,
+	}
+	if opts.Highlight {
+		m.highlight, _ = syntax.For(opts.Language)
+	}
+	m.game = m.newGame(nil)
+	return m, nil
+}
+
+// newGame creates a game session using the model's settings, typing words if given
+func (m *Model) newGame(words []string) *game.TypingGame {
+	var g *game.TypingGame
+	switch {
+	case words != nil:
+		g = game.NewTypingGameFromWords(m.duration, words)
+	case m.source != nil:
+		g = game.NewTypingGameFromWords(m.duration, m.source(200))
+	default:
+		g = game.NewTypingGame(m.duration)
+	}
+	g.WordSource = m.source
+	g.Backspace = m.backspace
+	g.FoldASCII = m.foldASCII
+	g.Difficulty = m.difficulty
+	g.SetNoSpace(slices.Contains(m.funbox, game.FunboxNoSpace))
+	g.SetIndent(m.indent, m.tabWidth)
+	if m.mode == history.ModeShell {
+		g.SetCommandLines(true)
+	}
+	return g
 }
 
-// restartTest resets the game state for a new typing test session
-func (m *Model) restartTest() {
-	m.game = game.NewTypingGame(m.duration)
+// restartTest resets the game state for a new typing test session, optionally with the same text
+func (m *Model) restartTest(sameText bool) {
+	var words []string
+	if sameText {
+		words = append([]string(nil), m.game.AllWords...)
+	}
+	m.game = m.newGame(words)
 	m.showResults = false
+	m.resultsPage = pageOverview
+	m.status = ""
 	m.finalStats = game.TypingStats{}
 }
 
+// finishTest captures the final stats, switches to the results screen and saves the result
+func (m *Model) finishTest() {
+	m.finalStats = m.game.GetStats()
+	m.showResults = true
+	m.record = history.NewRecord(m.game, m.finalStats, m.language, m.mode)
+	if m.emulating() {
+		m.record.Layout = m.layout.Name
+	}
+	if m.visibility != VisibilityNormal {
+		m.record.Visibility = m.visibility.String()
+	}
+	m.record.Funbox = m.funbox
+
+	if m.store == nil {
+		return
+	}
+	m.saveErr = m.store.Append(m.record)
+}
+
+// saveCard writes the shareable SVG and text cards to the working directory
+func (m *Model) saveCard() {
+	svgPath, _, err := share.WriteFiles(m.record, ".")
+	if err != nil {
+		m.status = err.Error()
+		return
+	}
+	m.status = "card saved to " + svgPath
+}
+
+// copyCard copies the plain text card to the clipboard over OSC52, reporting
+// the outcome with a cardCopiedMsg
+func (m Model) copyCard() tea.Cmd {
+	text := share.Plain(m.record)
+	return func() tea.Msg {
+		return cardCopiedMsg{err: share.CopyToClipboard(os.Stdout, text)}
+	}
+}
+
+// emulating reports whether typed keys are remapped to another layout
+func (m Model) emulating() bool {
+	return m.layout.Name != "" && m.layout.Name != keyboard.Physical
+}
+
+// cycleKeyboard switches the heatmap to the next built-in keyboard layout
+func (m *Model) cycleKeyboard() {
+	names := keyboard.Names()
+	for i, name := range names {
+		if name == m.keyboard.Name {
+			m.keyboard, _ = keyboard.Get(names[(i+1)%len(names)])
+			return
+		}
+	}
+	m.keyboard, _ = keyboard.Get(names[0])
+}
+
 // Init initializes the model and starts the tick command for periodic updates
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
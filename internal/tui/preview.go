@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/ashish0kumar/typtea/internal/theme"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RenderThemePreview applies the theme and renders a short sample of every styled element
+func RenderThemePreview(t theme.Theme) string {
+	ApplyTheme(t)
+
+	text := boldStyle.Render("the quick ") +
+		errorStyle.Render("v") +
+		boldStyle.Render("rown ") +
+		cursorStyle.Render("f") +
+		mutedStyle.Render("ox jumps over")
+
+	code := keywordStyle.Render("return") + mutedStyle.Render(" f(") +
+		stringStyle.Render(`"ok"`) + mutedStyle.Render(", ") +
+		numberStyle.Render("42") + mutedStyle.Render(") ") +
+		commentStyle.Render("// done")
+
+	results := lipgloss.JoinHorizontal(
+		lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Right, resultLabelStyle.Render("wpm"), resultValueStyle.Render("72")),
+		strings.Repeat(" ", statGap),
+		lipgloss.JoinVertical(lipgloss.Right, resultLabelStyle.Render("acc"), resultValueStyle.Render("96%")),
+	)
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		boldStyle.Render(t.Name),
+		lipgloss.JoinHorizontal(lipgloss.Top, timeStyle.MarginLeft(2).Render("27"), "  ", text),
+		lipgloss.NewStyle().MarginLeft(6).Render(code),
+		lipgloss.NewStyle().MarginLeft(2).Render(results),
+	)
+}
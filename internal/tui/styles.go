@@ -1,13 +1,15 @@
 package tui
 
 import (
+	"github.com/ashish0kumar/typtea/internal/share"
+	"github.com/ashish0kumar/typtea/internal/theme"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Styles for the TUI
+// Styles for the TUI. Colors are filled in by ApplyTheme.
 var (
 	timeStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("12")).
 			Bold(true).
 			MarginLeft(8)
 
@@ -21,20 +23,74 @@ var (
 	boldStyle = lipgloss.NewStyle().
 			Bold(true)
 
-	mutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("8"))
+	mutedStyle = lipgloss.NewStyle()
 
 	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("9")).
 			Bold(true).
 			Underline(true)
 
 	cursorStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("15")).
-			Foreground(lipgloss.Color("#000")).
 			Bold(true)
 
+	caretUnderlineStyle = lipgloss.NewStyle().
+				Underline(true).
+				Bold(true)
+
 	resultsContainerStyle = lipgloss.NewStyle().
 				Padding(3, 5).
 				Align(lipgloss.Left)
+
+	resultLabelStyle = lipgloss.NewStyle()
+
+	resultValueStyle = lipgloss.NewStyle().
+				Bold(true)
+
+	// Untyped code tokens, dimmed like mutedStyle but colored by kind
+	keywordStyle = lipgloss.NewStyle()
+	stringStyle  = lipgloss.NewStyle()
+	numberStyle  = lipgloss.NewStyle()
+	commentStyle = lipgloss.NewStyle()
+
+	heatKeyStyle = lipgloss.NewStyle().
+			Bold(true)
+
+	helpBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			Padding(1, 3)
 )
+
+// init applies the embedded default theme so styles are usable before a theme
+// is chosen. User theme files are only read, and their errors reported, when a
+// command applies its theme.
+func init() {
+	if t, err := theme.Default(); err == nil {
+		ApplyTheme(t)
+	}
+}
+
+// ApplyTheme sets the colors of every style from the given theme
+func ApplyTheme(t theme.Theme) {
+	timeStyle = timeStyle.Foreground(t.Timer.Adaptive())
+	boldStyle = boldStyle.Foreground(t.Typed.Adaptive())
+	mutedStyle = mutedStyle.Foreground(t.Muted.Adaptive())
+	errorStyle = errorStyle.Foreground(t.Error.Adaptive())
+	cursorStyle = cursorStyle.
+		Foreground(t.CursorText.Adaptive()).
+		Background(t.CursorBg.Adaptive())
+	caretUnderlineStyle = caretUnderlineStyle.Foreground(t.Typed.Adaptive())
+	resultLabelStyle = resultLabelStyle.Foreground(t.ResultLabel.Adaptive())
+	resultValueStyle = resultValueStyle.Foreground(t.ResultValue.Adaptive())
+	keywordStyle = keywordStyle.Foreground(t.Keyword.Adaptive())
+	stringStyle = stringStyle.Foreground(t.String.Adaptive())
+	numberStyle = numberStyle.Foreground(t.Number.Adaptive())
+	commentStyle = commentStyle.Foreground(t.Comment.Adaptive())
+	heatKeyStyle = heatKeyStyle.Foreground(t.HeatmapLabel.Adaptive())
+	if len(t.HeatmapScale) > 0 {
+		heatPalette = heatPalette[:0]
+		for _, c := range t.HeatmapScale {
+			heatPalette = append(heatPalette, c.Adaptive())
+		}
+	}
+	helpBoxStyle = helpBoxStyle.BorderForeground(t.Muted.Adaptive())
+	share.ApplyTheme(t)
+}
@@ -1,6 +1,11 @@
 package tui
 
 import (
+	"unicode"
+
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keymap"
+
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -15,17 +20,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Handle keyboard input and game logic
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "esc":
-			return m, tea.Quit
-
-		case "enter":
-			if m.showResults {
-				m.restartTest()
-				return m, tickCmd()
+		key := msg.String()
+
+		// A pending quit confirmation is settled by the very next key
+		if m.confirmingQuit {
+			m.confirmingQuit = false
+			m.status = ""
+			if action, ok := m.keys.Action(key, m.keyContext()); ok && action == keymap.Quit {
+				return m, tea.Quit
 			}
 			return m, nil
+		}
+
+		// Enter completes each command and Tab indents in shell mode, so neither
+		// can be bound to an action there
+		if (key == "enter" || key == "tab") && m.game.CommandLines && !m.showResults && !m.showHelp {
+			if !m.game.IsFinished && !m.game.IsTimeUp() {
+				if key == "enter" {
+					m.game.AddCharacter('\n')
+				} else {
+					m.game.AddTab()
+				}
+			}
+			return m, nil
+		}
+
+		if action, ok := m.keys.Action(key, m.keyContext()); ok {
+			return m.handleAction(action)
+		}
+
+		// The help overlay swallows everything but its own bindings
+		if m.showHelp {
+			return m, nil
+		}
 
+		switch key {
 		case " ":
 			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() {
 				m.game.AddCharacter(' ')
@@ -39,22 +68,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 
 		default:
-			// Handle regular character input
-			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() {
-				runes := []rune(msg.String())
-				if len(runes) == 1 && runes[0] >= 32 && runes[0] <= 126 {
-					m.game.AddCharacter(runes[0])
+			// Handle regular character input. IMEs and dead keys may deliver several
+			// runes at once; pasted text and alt chords are ignored.
+			if !m.showResults && !m.game.IsFinished && !m.game.IsTimeUp() &&
+				msg.Type == tea.KeyRunes && !msg.Alt && !msg.Paste {
+				for _, r := range msg.Runes {
+					if unicode.IsPrint(r) || game.IsCombining(r) {
+						if m.emulating() {
+							r = m.layout.Translate(r)
+						}
+						m.game.AddCharacter(r)
+					}
 				}
 			}
 			return m, nil
 		}
 
+	// Report the outcome of copying the share card
+	case cardCopiedMsg:
+		if msg.err != nil {
+			m.status = "could not copy card: " + msg.err.Error()
+		} else {
+			m.status = "card copied to clipboard"
+		}
+		return m, nil
+
 	// Handle tick messages for periodic updates
 	case tickMsg:
 		if !m.showResults {
-			if m.game.IsTimeUp() && m.game.IsStarted {
-				m.finalStats = m.game.GetStats()
-				m.showResults = true
+			// A test also ends early when a difficulty modifier fails it
+			if (m.game.IsTimeUp() || m.game.IsFinished) && m.game.IsStarted {
+				m.finishTest()
 				return m, nil
 			}
 			return m, tickCmd()
@@ -64,3 +108,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	return m, nil
 }
+
+// keyContext returns the keymap context for the current screen
+func (m Model) keyContext() keymap.Context {
+	if m.showResults {
+		return keymap.ContextResults
+	}
+	return keymap.ContextTest
+}
+
+// handleAction performs a bound action
+func (m Model) handleAction(action keymap.Action) (tea.Model, tea.Cmd) {
+	switch action {
+	case keymap.Quit:
+		if m.showHelp {
+			m.showHelp = false
+			return m, nil
+		}
+		if m.confirmQuit {
+			m.confirmingQuit = true
+			m.status = "press " + m.keys.Keys(keymap.Quit)[0] + " again to quit"
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case keymap.Restart, keymap.Retry:
+		// The tick loop stops on the results screen, so only restart it from there
+		wasResults := m.showResults
+		m.restartTest(action == keymap.Retry)
+		m.showHelp = false
+		if wasResults {
+			return m, tickCmd()
+		}
+		return m, nil
+
+	case keymap.Help:
+		m.showHelp = !m.showHelp
+
+	case keymap.NextPage:
+		m.resultsPage = (m.resultsPage + 1) % resultsPageCount
+	case keymap.HeatmapMetric:
+		m.heatmap = (m.heatmap + 1) % 2
+	case keymap.HeatmapLayout:
+		m.cycleKeyboard()
+	case keymap.SaveCard:
+		m.saveCard()
+	case keymap.CopyCard:
+		return m, m.copyCard()
+	}
+	return m, nil
+}
@@ -2,8 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/ashish0kumar/typtea/internal/game"
+	"github.com/ashish0kumar/typtea/internal/keyboard"
+	"github.com/ashish0kumar/typtea/internal/keymap"
+	"github.com/ashish0kumar/typtea/internal/syntax"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -12,6 +18,14 @@ const spacer = ""
 
 // View renders the current state of the Model as a string for display
 func (m Model) View() string {
+	if m.showHelp {
+		return lipgloss.Place(
+			m.width, m.height,
+			lipgloss.Center, lipgloss.Center,
+			m.renderHelp(),
+		)
+	}
+
 	if m.showResults {
 		return m.renderResults()
 	}
@@ -24,6 +38,10 @@ func (m Model) View() string {
 	textDisplay := m.renderText()
 	sections = append(sections, textDisplay)
 
+	if m.status != "" {
+		sections = append(sections, mutedStyle.MarginLeft(8).Render(m.status))
+	}
+
 	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	return lipgloss.Place(
@@ -33,33 +51,37 @@ func (m Model) View() string {
 	)
 }
 
-// renderTimer formats the remaining time for display
+// renderTimer formats the remaining time for display, followed by live stats if enabled
 func (m Model) renderTimer() string {
 	remaining := m.game.GetRemainingTime()
-	return timeStyle.Render(fmt.Sprintf("%d", remaining))
+	timer := timeStyle.Render(fmt.Sprintf("%d", remaining))
+
+	if !m.liveStats || !m.game.IsStarted {
+		return timer
+	}
+
+	stats := m.game.GetStats()
+	live := mutedStyle.Render(fmt.Sprintf("%.0f wpm  %.0f%%", stats.WPM, stats.Accuracy))
+	if m.visibility == VisibilityBlind {
+		// Accuracy would give mistakes away
+		live = mutedStyle.Render(fmt.Sprintf("%.0f wpm", stats.WPM))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, timer, strings.Repeat(" ", statGap), live)
 }
 
 // renderText formats the text display with appropriate styles for typed, current, untyped characters
 func (m Model) renderText() string {
-	displayText := m.game.GetDisplayText()
-
-	var rendered strings.Builder
-
-	for i, char := range displayText {
-		// Use helper to style character
-		styledChar := m.styleChar(char, i)
-		rendered.WriteString(styledChar)
+	lines := m.formatIntoLines()
+	box := textBoxStyle
+	if m.flowsLeft() {
+		box = box.Align(lipgloss.Right)
 	}
-
-	// Format into lines
-	content := rendered.String()
-	lines := m.formatIntoLines(content)
-
-	return textBoxStyle.Render(strings.Join(lines, "\n"))
+	return box.Render(strings.Join(lines, "\n"))
 }
 
-// formatIntoLines formats the content into lines based on the game's display settings
-func (m Model) formatIntoLines(plainContent string) []string {
+// formatIntoLines styles each display line one grapheme cluster at a time and lays
+// the clusters out in visual order, so right-to-left text reads correctly
+func (m Model) formatIntoLines() []string {
 	lines := m.game.DisplayLines
 
 	maxLines := m.game.LinesPerView
@@ -68,64 +90,124 @@ func (m Model) formatIntoLines(plainContent string) []string {
 	}
 
 	var styledLines []string
-	charIndex := 0
 
 	for i, line := range lines {
-		if i >= maxLines {
-			break
-		}
-
 		var styledLine strings.Builder
+		if m.game.CommandLines && line != "" {
+			// Later lines of a multi-line command line up under the first
+			prompt := m.prompt
+			if m.game.ContinuesCommand(i) {
+				prompt = strings.Repeat(" ", game.StringWidth(prompt))
+			}
+			styledLine.WriteString(mutedStyle.Render(prompt))
+		}
 
-		lineRunes := []rune(line)
+		clusters := game.Graphemes(line)
+		kinds := make([]syntax.Kind, len(clusters))
+		if m.highlight != nil {
+			kinds = m.highlight.Kinds(clusters)
+		}
 
-		for col := 0; col < len(lineRunes); col++ {
-			if charIndex < len(plainContent) {
-				styledChar := m.styleChar(lineRunes[col], charIndex)
-				styledLine.WriteString(styledChar)
-				charIndex++
+		// Style in logical order so positions and errors line up with the game
+		cells := make([]string, len(clusters))
+		for col, cluster := range clusters {
+			// A tab typed with the Tab key is shown as an arrow filling the tab stop
+			if cluster == "\t" {
+				cluster = "→" + strings.Repeat(" ", max(0, m.game.TabWidth-1))
+			}
+			if m.masked(i, col, clusters) {
+				cluster, kinds[col] = m.mask(cluster), syntax.Plain
+			}
+			if i == 0 {
+				cells[col] = m.styleChar(cluster, col, kinds[col])
 			} else {
-				styledLine.WriteString(mutedStyle.Render(string(lineRunes[col])))
+				cells[col] = untypedStyle(kinds[col]).Render(cluster)
 			}
 		}
 
-		// Check if caret is on this line and positioned just beyond last char
+		// Check if caret is on this line and positioned just beyond last char;
+		// that is the left edge of a right-to-left line
 		caretPos := m.game.CurrentPos
-		if i == 0 && caretPos == len(lineRunes) {
-			// Append caret style with a space or block to show cursor
-			styledLine.WriteString(cursorStyle.Render(" "))
+		endCaret := i == 0 && caretPos == len(clusters)
+		if endCaret && m.flowsLeft() {
+			styledLine.WriteString(m.caretStyle().Render(" "))
 		}
 
-		styledLines = append(styledLines, styledLine.String())
+		order := visualOrder(clusters, m.rtl)
+		if m.mirrored() {
+			slices.Reverse(order)
+		}
+		for _, col := range order {
+			styledLine.WriteString(cells[col])
+		}
 
-		// No extra space between lines
-		// charIndex++ // This was adding an extra space
+		if endCaret && !m.flowsLeft() {
+			// Append caret style with a space or block to show cursor, or a
+			// return symbol where a command is completed with Enter
+			end := " "
+			if m.game.CommandLines {
+				end = "⏎"
+			}
+			styledLine.WriteString(m.caretStyle().Render(end))
+		}
+
+		styledLines = append(styledLines, styledLine.String())
 	}
 
 	return styledLines
 }
 
-// styleChar determines the style of a character based on its position and error status
-func (m Model) styleChar(char rune, index int) string {
+// styleChar determines the style of a grapheme cluster based on its position and error status
+func (m Model) styleChar(cluster string, index int, kind syntax.Kind) string {
 	userPos := m.game.CurrentPos
-	errorIndex := m.game.GlobalPos - (userPos - index)
 
 	switch {
 	case index < userPos:
-		// Already typed
-		if m.game.Errors != nil {
-			if _, hasErr := m.game.Errors[errorIndex]; hasErr {
-				return errorStyle.Render(string(char))
-			}
+		// Already typed, or indentation skipped over; blind mode keeps mistakes for the results
+		if m.visibility != VisibilityBlind && m.game.HasError(index) {
+			return errorStyle.Render(cluster)
 		}
-		return boldStyle.Render(string(char))
+		return boldStyle.Render(cluster)
 	case index == userPos:
 		// Current character
-		return cursorStyle.Render(string(char))
+		return m.caretStyle().Render(cluster)
 	default:
 		// Not yet typed
-		return mutedStyle.Render(string(char))
+		return untypedStyle(kind).Render(cluster)
+	}
+}
+
+// untypedStyle returns the style of text not yet typed, colored by its syntax kind
+func untypedStyle(kind syntax.Kind) lipgloss.Style {
+	switch kind {
+	case syntax.Keyword:
+		return keywordStyle
+	case syntax.String:
+		return stringStyle
+	case syntax.Number:
+		return numberStyle
+	case syntax.Comment:
+		return commentStyle
+	}
+	return mutedStyle
+}
+
+// mirrored reports whether the mirror funbox flips lines horizontally
+func (m Model) mirrored() bool {
+	return slices.Contains(m.funbox, game.FunboxMirror)
+}
+
+// flowsLeft reports whether lines run from right to left, by script or by mirroring
+func (m Model) flowsLeft() bool {
+	return m.rtl != m.mirrored()
+}
+
+// caretStyle returns the style used to highlight the current character
+func (m Model) caretStyle() lipgloss.Style {
+	if m.caret == CaretUnderline {
+		return caretUnderlineStyle
 	}
+	return cursorStyle
 }
 
 // renderResults formats the final results of the typing test for display
@@ -134,31 +216,30 @@ func (m Model) renderResults() string {
 
 	accSection := lipgloss.JoinVertical(
 		lipgloss.Right,
-		mutedStyle.Render("acc"),
-		boldStyle.Render(fmt.Sprintf("%.0f%%", stats.Accuracy)),
+		resultLabelStyle.Render("acc"),
+		resultValueStyle.Render(fmt.Sprintf("%.0f%%", stats.Accuracy)),
 	)
 
 	wpmSection := lipgloss.JoinVertical(
 		lipgloss.Right,
-		mutedStyle.Render("wpm"),
-		boldStyle.Render(fmt.Sprintf("%.0f", stats.WPM)),
+		resultLabelStyle.Render("wpm"),
+		resultValueStyle.Render(fmt.Sprintf("%.0f", stats.WPM)),
 	)
 
 	timeSection := lipgloss.JoinVertical(
 		lipgloss.Right,
-		mutedStyle.Render("time"),
-		boldStyle.Render(fmt.Sprintf("%.0fs", stats.TimeElapsed.Seconds())),
+		resultLabelStyle.Render("time"),
+		resultValueStyle.Render(fmt.Sprintf("%.0fs", stats.TimeElapsed.Seconds())),
 	)
 
 	languageSection := lipgloss.JoinVertical(
 		lipgloss.Right,
-		mutedStyle.Render("lang"),
-		boldStyle.Render(m.language),
+		resultLabelStyle.Render("lang"),
+		resultValueStyle.Render(m.language),
 	)
 
 	// Arrange stats horizontally
-	statsRow := lipgloss.JoinHorizontal(
-		lipgloss.Top,
+	sectionsRow := []string{
 		accSection,
 		strings.Repeat(" ", statGap),
 		wpmSection,
@@ -166,18 +247,55 @@ func (m Model) renderResults() string {
 		timeSection,
 		strings.Repeat(" ", statGap),
 		languageSection,
-	)
-
-	instructions := mutedStyle.Align(lipgloss.Center).Render("Press Enter to restart • Esc to quit")
+	}
+	if m.emulating() {
+		layoutSection := lipgloss.JoinVertical(
+			lipgloss.Right,
+			resultLabelStyle.Render("layout"),
+			resultValueStyle.Render(m.layout.Name),
+		)
+		sectionsRow = append(sectionsRow, strings.Repeat(" ", statGap), layoutSection)
+	}
+	if m.difficulty != game.DifficultyNormal {
+		difficultySection := lipgloss.JoinVertical(
+			lipgloss.Right,
+			resultLabelStyle.Render("difficulty"),
+			resultValueStyle.Render(m.difficulty.String()),
+		)
+		sectionsRow = append(sectionsRow, strings.Repeat(" ", statGap), difficultySection)
+	}
+	if len(m.funbox) > 0 {
+		funboxSection := lipgloss.JoinVertical(
+			lipgloss.Right,
+			resultLabelStyle.Render("funbox"),
+			resultValueStyle.Render(strings.Join(m.funbox, " ")),
+		)
+		sectionsRow = append(sectionsRow, strings.Repeat(" ", statGap), funboxSection)
+	}
+	statsRow := lipgloss.JoinHorizontal(lipgloss.Top, sectionsRow...)
 
 	// Results layout
-	resultsContent := lipgloss.JoinVertical(
-		lipgloss.Center,
+	sections := []string{
 		spacer,
 		statsRow,
 		spacer,
-		instructions,
-	)
+	}
+	if m.game.FailReason != "" {
+		sections = append(sections, errorStyle.Render("failed: "+m.game.FailReason), spacer)
+	}
+	if note := m.visibility.scoringNote(); note != "" {
+		sections = append(sections, mutedStyle.Render(note), spacer)
+	}
+	sections = append(sections, m.renderResultsPage(), spacer)
+	if m.saveErr != nil {
+		sections = append(sections, errorStyle.Render("history not saved: "+m.saveErr.Error()), spacer)
+	}
+	if m.status != "" {
+		sections = append(sections, boldStyle.Render(m.status), spacer)
+	}
+	sections = append(sections, m.renderResultsInstructions())
+
+	resultsContent := lipgloss.JoinVertical(lipgloss.Center, sections...)
 
 	return lipgloss.Place(
 		m.width, m.height,
@@ -185,3 +303,74 @@ func (m Model) renderResults() string {
 		resultsContainerStyle.Render(resultsContent),
 	)
 }
+
+// renderResultsPage renders the body of the currently selected results page
+func (m Model) renderResultsPage() string {
+	switch m.resultsPage {
+	case pageAnalysis:
+		return RenderAnalysis(game.Analyze([][]game.Keystroke{m.game.Keystrokes}, analysisLimit))
+	case pageFingers:
+		usage := RenderFingerUsage(game.ComputeFingerUsage([][]game.Keystroke{m.game.Keystrokes}, []keyboard.Layout{m.keyboard}))
+		return lipgloss.JoinVertical(lipgloss.Center, mutedStyle.Render(m.keyboard.Name), usage)
+	default:
+		heatmap := RenderHeatmap(game.ComputeKeyStats(m.game.Keystrokes), m.keyboard, m.heatmap)
+		return lipgloss.JoinVertical(lipgloss.Center, mutedStyle.Render(m.keyboard.Name), heatmap)
+	}
+}
+
+// renderResultsInstructions lists the main keys available on the results screen
+func (m Model) renderResultsInstructions() string {
+	hints := []string{
+		m.keyHint(keymap.Restart, "restart"),
+		m.keyHint(keymap.NextPage, "next page"),
+	}
+	if m.resultsPage == pageOverview {
+		hints = append(hints, m.keyHint(keymap.HeatmapMetric, "metric"))
+	}
+	if m.resultsPage != pageAnalysis {
+		hints = append(hints, m.keyHint(keymap.HeatmapLayout, "keyboard"))
+	}
+	hints = append(hints,
+		m.keyHint(keymap.Help, "help"),
+		m.keyHint(keymap.Quit, "quit"),
+	)
+
+	var shown []string
+	for _, h := range hints {
+		if h != "" {
+			shown = append(shown, h)
+		}
+	}
+	return mutedStyle.Align(lipgloss.Center).Render(strings.Join(shown, " • "))
+}
+
+// keyHint formats the first key of an action with a label, or "" if it is unbound
+func (m Model) keyHint(action keymap.Action, label string) string {
+	keys := m.keys.Keys(action)
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0] + " " + label
+}
+
+// renderHelp lists every keybinding in a bordered overlay
+func (m Model) renderHelp() string {
+	var rows []string
+	rows = append(rows, boldStyle.Render("keybindings"), spacer)
+
+	entries := m.keys.Help()
+	width := 0
+	for _, e := range entries {
+		width = max(width, len(e.Keys))
+	}
+	for _, e := range entries {
+		description := e.Description
+		if e.ResultsOnly {
+			description += mutedStyle.Render(" (results)")
+		}
+		rows = append(rows, boldStyle.Render(fmt.Sprintf("%-*s", width, e.Keys))+"   "+description)
+	}
+
+	rows = append(rows, spacer, mutedStyle.Render(m.keyHint(keymap.Help, "or "+m.keys.Keys(keymap.Quit)[0]+" to close")))
+	return helpBoxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
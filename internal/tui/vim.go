@@ -0,0 +1,270 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/vim"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// VimOptions configures the Vim motion trainer
+type VimOptions struct {
+	Tasks func() []vim.Task // Draws a fresh set of tasks for each round
+}
+
+// vimResult is the outcome of a single task
+type vimResult struct {
+	task       vim.Task
+	elapsed    time.Duration
+	keystrokes int
+	skipped    bool
+}
+
+// VimTrainer runs Vim editing tasks with real Normal mode keys
+type VimTrainer struct {
+	opts       VimOptions
+	width      int
+	height     int
+	tasks      []vim.Task
+	index      int
+	engine     *vim.Engine
+	keystrokes int
+	started    time.Time // Zero until the first key of the task
+	results    []vimResult
+	finished   bool
+}
+
+// NewVim creates the trainer with a first round of tasks
+func NewVim(opts VimOptions) *VimTrainer {
+	v := &VimTrainer{opts: opts}
+	v.newRound()
+	return v
+}
+
+// newRound draws new tasks and starts the first one
+func (v *VimTrainer) newRound() {
+	v.tasks = v.opts.Tasks()
+	v.results = nil
+	v.finished = false
+	v.index = 0
+	v.startTask()
+}
+
+// startTask resets the buffer and counters for the current task
+func (v *VimTrainer) startTask() {
+	v.engine = v.tasks[v.index].Engine()
+	v.keystrokes = 0
+	v.started = time.Time{}
+}
+
+// finishTask records the current task and moves on to the next one
+func (v *VimTrainer) finishTask(skipped bool) {
+	result := vimResult{task: v.tasks[v.index], keystrokes: v.keystrokes, skipped: skipped}
+	if !v.started.IsZero() {
+		result.elapsed = time.Since(v.started)
+	}
+	v.results = append(v.results, result)
+
+	v.index++
+	if v.index >= len(v.tasks) {
+		v.finished = true
+		return
+	}
+	v.startTask()
+}
+
+// Init implements tea.Model
+func (v VimTrainer) Init() tea.Cmd {
+	return nil
+}
+
+// Update feeds keys to the Vim engine and checks the task after each one
+func (v VimTrainer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		v.width, v.height = msg.Width, msg.Height
+	case tickMsg:
+		// Keep the timer moving while a task is in progress
+		if !v.finished && !v.started.IsZero() {
+			return v, tickCmd()
+		}
+	case tea.KeyMsg:
+		if v.finished {
+			switch msg.String() {
+			case "ctrl+c", "esc", "q":
+				return v, tea.Quit
+			case "enter", "r":
+				v.newRound()
+			}
+			return v, nil
+		}
+		return v.updateTask(msg)
+	}
+	return v, nil
+}
+
+// updateTask handles a key while a task is on screen. Esc belongs to Vim, so
+// the trainer's own actions use control keys.
+func (v VimTrainer) updateTask(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return v, tea.Quit
+	case "ctrl+r":
+		v.startTask()
+		return v, nil
+	case "ctrl+n":
+		v.finishTask(true)
+		return v, nil
+	}
+	if msg.Alt || msg.Paste {
+		return v, nil
+	}
+
+	var cmd tea.Cmd
+	if v.started.IsZero() {
+		v.started = time.Now()
+		cmd = tickCmd()
+	}
+	v.keystrokes++
+	v.engine.Feed(msg.String())
+
+	if v.tasks[v.index].Done(v.engine) {
+		v.finishTask(false)
+	}
+	return v, cmd
+}
+
+// View renders the current task or the round summary
+func (v VimTrainer) View() string {
+	var content string
+	if v.finished {
+		content = v.renderSummary()
+	} else {
+		content = v.renderTask()
+	}
+	return lipgloss.Place(
+		v.width, v.height,
+		lipgloss.Center, lipgloss.Center,
+		content,
+	)
+}
+
+// renderTask shows the prompt, the live buffer and the buffer to reach
+func (v VimTrainer) renderTask() string {
+	task := v.tasks[v.index]
+
+	var elapsed time.Duration
+	if !v.started.IsZero() {
+		elapsed = time.Since(v.started)
+	}
+
+	mode := mutedStyle.Render("-- NORMAL --")
+	if v.engine.Mode() == vim.Insert {
+		mode = boldStyle.Render("-- INSERT --")
+	}
+	if pending := v.engine.Pending(); pending != "" {
+		mode += "  " + boldStyle.Render(pending)
+	}
+
+	cursor := v.engine.Cursor()
+	cursorCell := cursorStyle
+	if v.engine.Mode() == vim.Insert {
+		cursorCell = caretUnderlineStyle
+	}
+
+	rows := []string{
+		timeStyle.MarginLeft(0).Render(fmt.Sprintf("vim %d/%d", v.index+1, len(v.tasks))),
+		spacer,
+		boldStyle.Render(task.Prompt),
+		spacer,
+		renderBuffer(v.engine.Lines(), &cursor, boldStyle, cursorCell),
+		spacer,
+		mutedStyle.Render("target"),
+		renderBuffer(task.Want, task.WantCursor, mutedStyle, caretUnderlineStyle),
+		spacer,
+		mode,
+		resultLabelStyle.Render(fmt.Sprintf("keys %d", v.keystrokes)) +
+			mutedStyle.Render(fmt.Sprintf(" (best %d)  ", task.Optimal())) +
+			resultLabelStyle.Render(fmt.Sprintf("%.1fs", elapsed.Seconds())),
+	}
+	if n := len(v.results); n > 0 && !v.results[n-1].skipped {
+		last := v.results[n-1]
+		rows = append(rows, mutedStyle.Render(fmt.Sprintf("last: %d keys in %.1fs (best %d)",
+			last.keystrokes, last.elapsed.Seconds(), last.task.Optimal())))
+	}
+	rows = append(rows, spacer, mutedStyle.Render("ctrl+r restart task • ctrl+n skip • ctrl+c quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderBuffer draws buffer lines with line numbers, marking the cursor cell if there is one
+func renderBuffer(lines []string, cursor *vim.Position, style, cursorCell lipgloss.Style) string {
+	rows := make([]string, len(lines))
+	for i, line := range lines {
+		var b strings.Builder
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("%2d ", i+1)))
+		runes := []rune(line)
+		for col, r := range runes {
+			if cursor != nil && cursor.Row == i && cursor.Col == col {
+				b.WriteString(cursorCell.Render(string(r)))
+			} else {
+				b.WriteString(style.Render(string(r)))
+			}
+		}
+		if cursor != nil && cursor.Row == i && cursor.Col >= len(runes) {
+			b.WriteString(cursorCell.Render(" "))
+		}
+		rows[i] = b.String()
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// renderSummary lists every task of the round with its time and keystrokes
+func (v VimTrainer) renderSummary() string {
+	rows := []string{timeStyle.MarginLeft(0).Render("vim results"), spacer}
+
+	var total time.Duration
+	var keystrokes, optimal, solved int
+	for _, r := range v.results {
+		prompt := fmt.Sprintf("%-46s", truncate(r.task.Prompt, 44))
+		if r.skipped {
+			rows = append(rows, "  "+resultLabelStyle.Render(prompt)+mutedStyle.Render("skipped"))
+			continue
+		}
+		solved++
+		total += r.elapsed
+		keystrokes += r.keystrokes
+		optimal += r.task.Optimal()
+
+		mark := "  "
+		if r.keystrokes <= r.task.Optimal() {
+			mark = boldStyle.Render("✓ ")
+		}
+		rows = append(rows, mark+resultLabelStyle.Render(prompt)+
+			resultValueStyle.Render(fmt.Sprintf("%5.1fs %3d keys", r.elapsed.Seconds(), r.keystrokes))+
+			mutedStyle.Render(fmt.Sprintf("  best %d", r.task.Optimal())))
+	}
+
+	rows = append(rows, spacer)
+	if solved > 0 {
+		rows = append(rows, resultLabelStyle.Render("solved ")+resultValueStyle.Render(fmt.Sprintf("%d/%d", solved, len(v.results)))+
+			resultLabelStyle.Render("  time ")+resultValueStyle.Render(fmt.Sprintf("%.1fs", total.Seconds()))+
+			resultLabelStyle.Render("  efficiency ")+resultValueStyle.Render(fmt.Sprintf("%.0f%%", 100*float64(optimal)/float64(max(1, keystrokes)))))
+	} else {
+		rows = append(rows, mutedStyle.Render("no tasks solved"))
+	}
+	rows = append(rows, spacer, mutedStyle.Render("enter new round • esc quit"))
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// truncate shortens s to n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
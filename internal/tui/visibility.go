@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ashish0kumar/typtea/internal/config"
+	"github.com/ashish0kumar/typtea/internal/game"
+)
+
+// Visibility selects how much of the text is shown while typing. Its values
+// follow the order of the names in config.Visibilities.
+type Visibility int
+
+const (
+	VisibilityNormal Visibility = iota
+	VisibilityBlind             // Mistakes aren't marked until the results screen
+	VisibilityMemory            // The next line is shown briefly, then masked
+	VisibilityHidden            // Only the word being typed is shown
+)
+
+// memoryReveal is how long memory mode shows each new line
+const memoryReveal = 3 * time.Second
+
+// ParseVisibility converts a visibility name (normal, blind, memory, hidden) into a Visibility
+func ParseVisibility(name string) (Visibility, error) {
+	if name == "" {
+		return VisibilityNormal, nil
+	}
+	for i, v := range config.Visibilities {
+		if strings.EqualFold(name, v) {
+			return Visibility(i), nil
+		}
+	}
+	return VisibilityNormal, fmt.Errorf("unknown visibility '%s' (available: %s)", name, strings.Join(config.Visibilities, ", "))
+}
+
+// String returns the visibility name
+func (v Visibility) String() string {
+	if v < 0 || int(v) >= len(config.Visibilities) {
+		return config.Visibilities[0]
+	}
+	return config.Visibilities[v]
+}
+
+// scoringNote explains on the results screen how the visibility mode affected the test
+func (v Visibility) scoringNote() string {
+	switch v {
+	case VisibilityBlind:
+		return "blind: mistakes were hidden while typing and all count against accuracy"
+	case VisibilityMemory:
+		return fmt.Sprintf("memory: each line was shown for %.0fs, then typed from memory", memoryReveal.Seconds())
+	case VisibilityHidden:
+		return "hidden: only the current word was shown; scored like a normal test"
+	}
+	return ""
+}
+
+// masked reports whether a cluster not yet typed is hidden by the visibility mode
+func (m Model) masked(line, col int, clusters []string) bool {
+	if line == 0 && col < m.game.CurrentPos {
+		return false
+	}
+	switch m.visibility {
+	case VisibilityMemory:
+		return !m.revealed(line)
+	case VisibilityHidden:
+		return line > 0 || col >= currentWordEnd(clusters, m.game.CurrentPos)
+	}
+	return false
+}
+
+// revealed reports whether memory mode still shows a line. Everything is
+// visible until typing starts; after that only a new next line is, briefly.
+func (m Model) revealed(line int) bool {
+	if !m.game.IsStarted {
+		return true
+	}
+	if line != 1 {
+		return false
+	}
+	since := m.game.StartTime
+	if m.game.LinesShiftedAt.After(since) {
+		since = m.game.LinesShiftedAt
+	}
+	return time.Since(since) < memoryReveal
+}
+
+// mask replaces a cluster with filler of the same width: dots where text is
+// to be remembered, blanks where it isn't shown at all
+func (m Model) mask(cluster string) string {
+	filler := " "
+	if m.visibility == VisibilityMemory {
+		filler = "·"
+	}
+	return strings.Repeat(filler, max(1, game.StringWidth(cluster)))
+}
+
+// currentWordEnd returns the end of the word at pos; on the space before a
+// word, that word counts as current
+func currentWordEnd(clusters []string, pos int) int {
+	end := pos
+	if end < len(clusters) && clusters[end] == " " {
+		end++
+	}
+	for end < len(clusters) && clusters[end] != " " {
+		end++
+	}
+	return end
+}
@@ -0,0 +1,449 @@
+package vim
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Mode is the editing mode of the engine
+type Mode int
+
+const (
+	Normal Mode = iota
+	Insert
+)
+
+// Position is a cursor location in the buffer, counted in runes
+type Position struct {
+	Row int
+	Col int
+}
+
+// snapshot is a buffer state saved for undo
+type snapshot struct {
+	lines  [][]rune
+	cursor Position
+}
+
+// Engine interprets Normal and Insert mode keystrokes on a small buffer. It
+// supports the everyday subset of Vim: counts, motions, the d, c and y
+// operators with motions and text objects, put, undo and the insert commands.
+type Engine struct {
+	lines    [][]rune
+	cursor   Position
+	mode     Mode
+	pending  []string // Keys of an unfinished Normal mode command
+	register []rune   // Last deleted or yanked text
+	linewise bool     // Whether the register holds whole lines
+	undo     []snapshot
+}
+
+// New creates an engine editing lines with the cursor at the given position
+func New(lines []string, cursor Position) *Engine {
+	e := &Engine{cursor: cursor}
+	for _, l := range lines {
+		e.lines = append(e.lines, []rune(l))
+	}
+	if len(e.lines) == 0 {
+		e.lines = [][]rune{{}}
+	}
+	e.clamp()
+	return e
+}
+
+// Lines returns the buffer contents
+func (e *Engine) Lines() []string {
+	lines := make([]string, len(e.lines))
+	for i, l := range e.lines {
+		lines[i] = string(l)
+	}
+	return lines
+}
+
+// Cursor returns the cursor position
+func (e *Engine) Cursor() Position {
+	return e.cursor
+}
+
+// Mode returns the current editing mode
+func (e *Engine) Mode() Mode {
+	return e.mode
+}
+
+// Pending returns the keys of an unfinished command, e.g. "d2"
+func (e *Engine) Pending() string {
+	return strings.Join(e.pending, "")
+}
+
+// Feed processes a single key as reported by Bubble Tea, e.g. "d", "esc" or "enter"
+func (e *Engine) Feed(key string) {
+	if e.mode == Insert {
+		e.insertKey(key)
+		return
+	}
+	if key == "esc" {
+		e.pending = nil
+		return
+	}
+
+	e.pending = append(e.pending, key)
+	cmd, status := parse(e.pending)
+	switch status {
+	case incomplete:
+		return
+	case invalid:
+		e.pending = nil
+		return
+	}
+	e.pending = nil
+	e.execute(cmd)
+}
+
+// insertKey handles a key in Insert mode
+func (e *Engine) insertKey(key string) {
+	row, col := e.cursor.Row, e.cursor.Col
+	line := e.lines[row]
+
+	switch key {
+	case "esc":
+		e.mode = Normal
+		e.cursor.Col = max(0, col-1)
+	case "enter":
+		rest := slices.Clone(line[col:])
+		e.lines[row] = line[:col]
+		e.lines = slices.Insert(e.lines, row+1, rest)
+		e.cursor = Position{row + 1, 0}
+	case "backspace":
+		switch {
+		case col > 0:
+			e.lines[row] = slices.Delete(line, col-1, col)
+			e.cursor.Col--
+		case row > 0:
+			prev := e.lines[row-1]
+			e.cursor = Position{row - 1, len(prev)}
+			e.lines[row-1] = append(prev, line...)
+			e.lines = slices.Delete(e.lines, row, row+1)
+		}
+	default:
+		r, ok := keyRune(key)
+		if !ok || !unicode.IsPrint(r) {
+			return
+		}
+		e.lines[row] = slices.Insert(line, col, r)
+		e.cursor.Col++
+	}
+}
+
+// execute runs a complete Normal mode command
+func (e *Engine) execute(c command) {
+	count := max(1, c.count) * max(1, c.opCount)
+
+	if c.op != 0 {
+		e.save()
+		switch {
+		case c.linewise:
+			e.applyLines(c.op, e.cursor.Row, min(len(e.lines)-1, e.cursor.Row+count-1))
+		case c.object != "":
+			if start, end, ok := e.textObject(c.object, count); ok {
+				e.applyRange(c.op, start, end)
+			} else {
+				e.undo = e.undo[:len(e.undo)-1]
+			}
+		default:
+			e.applyMotion(c.op, c.action, c.arg, count, c.count > 0 || c.opCount > 0)
+		}
+		return
+	}
+
+	line := e.lines[e.cursor.Row]
+	switch c.action {
+	case "x":
+		if len(line) > 0 {
+			e.save()
+			e.applyRange('d', e.offset(e.cursor), e.offset(e.cursor)+min(count, len(line)-e.cursor.Col))
+		}
+	case "X":
+		if e.cursor.Col > 0 {
+			e.save()
+			start := e.offset(Position{e.cursor.Row, max(0, e.cursor.Col-count)})
+			e.applyRange('d', start, e.offset(e.cursor))
+		}
+	case "D":
+		e.execute(command{op: 'd', action: "$", count: c.count})
+	case "C":
+		e.execute(command{op: 'c', action: "$", count: c.count})
+	case "s":
+		e.execute(command{op: 'c', action: "l", count: c.count})
+	case "S":
+		e.execute(command{op: 'c', linewise: true, count: c.count})
+	case "Y":
+		e.execute(command{op: 'y', linewise: true, count: c.count})
+	case "p", "P":
+		e.put(c.action == "P", count)
+	case "i":
+		e.startInsert(e.cursor.Col)
+	case "a":
+		e.startInsert(min(len(line), e.cursor.Col+1))
+	case "I":
+		e.startInsert(firstNonBlank(line))
+	case "A":
+		e.startInsert(len(line))
+	case "o", "O":
+		e.save()
+		row := e.cursor.Row + 1
+		if c.action == "O" {
+			row = e.cursor.Row
+		}
+		e.lines = slices.Insert(e.lines, row, []rune{})
+		e.cursor = Position{row, 0}
+		e.mode = Insert
+	case "J":
+		if e.cursor.Row+1 < len(e.lines) {
+			e.save()
+			for i := 0; i < max(1, count-1) && e.cursor.Row+1 < len(e.lines); i++ {
+				e.join(e.cursor.Row)
+			}
+		}
+	case "~":
+		if len(line) > 0 {
+			e.save()
+			end := min(len(line), e.cursor.Col+count)
+			for i := e.cursor.Col; i < end; i++ {
+				line[i] = toggleCase(line[i])
+			}
+			e.cursor.Col = end
+		}
+	case "r":
+		if e.cursor.Col+count <= len(line) {
+			e.save()
+			for i := e.cursor.Col; i < e.cursor.Col+count; i++ {
+				line[i] = c.arg
+			}
+			e.cursor.Col += count - 1
+		}
+	case "u":
+		for i := 0; i < count && len(e.undo) > 0; i++ {
+			s := e.undo[len(e.undo)-1]
+			e.undo = e.undo[:len(e.undo)-1]
+			e.lines, e.cursor = s.lines, s.cursor
+		}
+	default:
+		if t, ok := e.motion(c.action, c.arg, count, c.count > 0, 0); ok {
+			e.cursor = t.pos
+			if t.linewise && (c.action == "gg" || c.action == "G") {
+				e.cursor.Col = firstNonBlank(e.lines[t.pos.Row])
+			}
+		}
+	}
+	e.clamp()
+}
+
+// applyMotion applies an operator from the cursor to the target of a motion
+func (e *Engine) applyMotion(op rune, name string, arg rune, count int, counted bool) {
+	t, ok := e.motion(name, arg, count, counted, op)
+	if !ok {
+		e.undo = e.undo[:len(e.undo)-1]
+		return
+	}
+	if t.linewise {
+		e.applyLines(op, min(e.cursor.Row, t.pos.Row), max(e.cursor.Row, t.pos.Row))
+		return
+	}
+
+	start, end := e.offset(e.cursor), e.offset(t.pos)
+	if start > end {
+		start, end = end, start
+	}
+	if t.inclusive {
+		end++
+	}
+	e.applyRange(op, start, min(end, len(e.flatten())))
+}
+
+// applyRange applies an operator to the characters between two buffer offsets
+func (e *Engine) applyRange(op rune, start, end int) {
+	flat := e.flatten()
+	e.register = slices.Clone(flat[start:end])
+	e.linewise = false
+	if op != 'y' {
+		e.setFlat(slices.Delete(flat, start, end))
+	}
+	e.cursor = e.position(start)
+	if op == 'c' {
+		e.mode = Insert
+		return
+	}
+	e.clamp()
+}
+
+// applyLines applies an operator to whole lines from first to last
+func (e *Engine) applyLines(op rune, first, last int) {
+	var yanked []rune
+	for i := first; i <= last; i++ {
+		yanked = append(append(yanked, e.lines[i]...), '\n')
+	}
+	e.register = yanked
+	e.linewise = true
+
+	switch op {
+	case 'y':
+		e.cursor.Row = first
+	case 'd':
+		e.lines = slices.Delete(e.lines, first, last+1)
+		if len(e.lines) == 0 {
+			e.lines = [][]rune{{}}
+		}
+		row := min(first, len(e.lines)-1)
+		e.cursor = Position{row, firstNonBlank(e.lines[row])}
+	case 'c':
+		indent := slices.Clone(e.lines[first][:firstNonBlank(e.lines[first])])
+		e.lines = slices.Replace(e.lines, first, last+1, indent)
+		e.cursor = Position{first, len(indent)}
+		e.mode = Insert
+		return
+	}
+	e.clamp()
+}
+
+// put inserts the register after or before the cursor
+func (e *Engine) put(before bool, count int) {
+	if len(e.register) == 0 {
+		return
+	}
+	e.save()
+
+	if e.linewise {
+		var lines [][]rune
+		text := e.register[:len(e.register)-1]
+		for range count {
+			for _, l := range strings.Split(string(text), "\n") {
+				lines = append(lines, []rune(l))
+			}
+		}
+		row := e.cursor.Row + 1
+		if before {
+			row = e.cursor.Row
+		}
+		e.lines = slices.Insert(e.lines, row, lines...)
+		e.cursor = Position{row, firstNonBlank(e.lines[row])}
+		return
+	}
+
+	var text []rune
+	for range count {
+		text = append(text, e.register...)
+	}
+	at := e.offset(e.cursor)
+	if !before && len(e.lines[e.cursor.Row]) > 0 {
+		at++
+	}
+	e.setFlat(slices.Insert(e.flatten(), at, text...))
+	e.cursor = e.position(at + len(text) - 1)
+	e.clamp()
+}
+
+// join appends the next line to row, separated by a single space
+func (e *Engine) join(row int) {
+	next := e.lines[row+1][firstNonBlank(e.lines[row+1]):]
+	line := e.lines[row]
+	col := len(line)
+	if len(line) > 0 && len(next) > 0 {
+		line = append(line, ' ')
+	}
+	e.lines[row] = append(line, next...)
+	e.lines = slices.Delete(e.lines, row+1, row+2)
+	e.cursor = Position{row, col}
+}
+
+// startInsert switches to Insert mode with the cursor at col
+func (e *Engine) startInsert(col int) {
+	e.save()
+	e.cursor.Col = col
+	e.mode = Insert
+}
+
+// save records the buffer for undo
+func (e *Engine) save() {
+	lines := make([][]rune, len(e.lines))
+	for i, l := range e.lines {
+		lines[i] = slices.Clone(l)
+	}
+	e.undo = append(e.undo, snapshot{lines: lines, cursor: e.cursor})
+}
+
+// clamp keeps the cursor on a character, as Normal mode requires
+func (e *Engine) clamp() {
+	e.cursor.Row = max(0, min(e.cursor.Row, len(e.lines)-1))
+	limit := len(e.lines[e.cursor.Row])
+	if e.mode == Normal {
+		limit--
+	}
+	e.cursor.Col = max(0, min(e.cursor.Col, limit))
+}
+
+// flatten returns the buffer as a single rune slice with newlines between lines
+func (e *Engine) flatten() []rune {
+	var flat []rune
+	for i, l := range e.lines {
+		if i > 0 {
+			flat = append(flat, '\n')
+		}
+		flat = append(flat, l...)
+	}
+	return flat
+}
+
+// setFlat replaces the buffer with flattened text
+func (e *Engine) setFlat(flat []rune) {
+	e.lines = nil
+	for _, l := range strings.Split(string(flat), "\n") {
+		e.lines = append(e.lines, []rune(l))
+	}
+}
+
+// offset converts a position into an index into the flattened buffer
+func (e *Engine) offset(p Position) int {
+	off := 0
+	for i := 0; i < p.Row; i++ {
+		off += len(e.lines[i]) + 1
+	}
+	return off + p.Col
+}
+
+// position converts an index into the flattened buffer back into a position
+func (e *Engine) position(off int) Position {
+	for row, l := range e.lines {
+		if off <= len(l) {
+			return Position{row, off}
+		}
+		off -= len(l) + 1
+	}
+	last := len(e.lines) - 1
+	return Position{last, len(e.lines[last])}
+}
+
+// firstNonBlank returns the column of the first non-blank character of a line
+func firstNonBlank(line []rune) int {
+	for i, r := range line {
+		if !unicode.IsSpace(r) {
+			return i
+		}
+	}
+	return 0
+}
+
+// toggleCase swaps the case of a letter
+func toggleCase(r rune) rune {
+	if unicode.IsUpper(r) {
+		return unicode.ToLower(r)
+	}
+	return unicode.ToUpper(r)
+}
+
+// keyRune returns the character typed by a single-character key
+func keyRune(key string) (rune, bool) {
+	r, size := utf8.DecodeRuneInString(key)
+	return r, size > 0 && size == len(key)
+}
@@ -0,0 +1,354 @@
+package vim
+
+import (
+	"strings"
+	"unicode"
+)
+
+// target is where a motion moves the cursor
+type target struct {
+	pos       Position
+	linewise  bool // Operators act on whole lines, like j or G
+	inclusive bool // Operators include the character at pos, like e or f
+}
+
+// motion computes the target of a motion repeated count times. counted tells
+// whether a count was typed, which changes gg and G. op is the operator applying
+// the motion, or 0 for a plain move, for the special cases of dw and cw.
+func (e *Engine) motion(name string, arg rune, count int, counted bool, op rune) (target, bool) {
+	cur := e.cursor
+	line := e.lines[cur.Row]
+
+	switch name {
+	case "h":
+		if cur.Col == 0 {
+			return target{}, false
+		}
+		return target{pos: Position{cur.Row, max(0, cur.Col-count)}}, true
+	case "l":
+		if len(line) == 0 {
+			return target{}, false
+		}
+		return target{pos: Position{cur.Row, min(len(line), cur.Col+count)}}, true
+	case "j", "k":
+		row := cur.Row + count
+		if name == "k" {
+			row = cur.Row - count
+		}
+		if row < 0 || row >= len(e.lines) {
+			return target{}, false
+		}
+		return target{pos: Position{row, cur.Col}, linewise: true}, true
+	case "0":
+		return target{pos: Position{cur.Row, 0}}, true
+	case "^":
+		return target{pos: Position{cur.Row, firstNonBlank(line)}}, true
+	case "$":
+		row := min(len(e.lines)-1, cur.Row+count-1)
+		return target{pos: Position{row, max(0, len(e.lines[row])-1)}, inclusive: len(e.lines[row]) > 0}, true
+	case "gg", "G":
+		row := len(e.lines) - 1
+		if name == "gg" {
+			row = 0
+		}
+		if counted {
+			row = min(len(e.lines)-1, count-1)
+		}
+		return target{pos: Position{row, 0}, linewise: true}, true
+	case "f", "t", "F", "T":
+		step := 1
+		if name == "F" || name == "T" {
+			step = -1
+		}
+		col := cur.Col
+		for range count {
+			col += step
+			for col >= 0 && col < len(line) && line[col] != arg {
+				col += step
+			}
+			if col < 0 || col >= len(line) {
+				return target{}, false
+			}
+		}
+		if name == "t" || name == "T" {
+			col -= step
+		}
+		return target{pos: Position{cur.Row, col}, inclusive: step > 0}, true
+	case "%":
+		return e.matchBracket()
+	case "w", "W":
+		return e.wordForward(name == "W", count, op)
+	case "e", "E":
+		flat := e.flatten()
+		off := e.offset(cur)
+		for range count {
+			off = wordEnd(flat, off, name == "E")
+		}
+		return target{pos: e.position(off), inclusive: true}, true
+	case "b", "B":
+		flat := e.flatten()
+		off := e.offset(cur)
+		for range count {
+			off = wordStart(flat, off, name == "B")
+		}
+		return target{pos: e.position(off)}, true
+	}
+	return target{}, false
+}
+
+// wordForward moves to the start of the count-th next word
+func (e *Engine) wordForward(bigWord bool, count int, op rune) (target, bool) {
+	flat := e.flatten()
+	off := e.offset(e.cursor)
+
+	// cw on a word changes up to its end, like ce
+	if op == 'c' && classAt(flat, off, bigWord) != blank {
+		for off+1 < len(flat) && classAt(flat, off+1, bigWord) == classAt(flat, off, bigWord) {
+			off++
+		}
+		for range count - 1 {
+			off = wordEnd(flat, off, bigWord)
+		}
+		return target{pos: e.position(off), inclusive: true}, true
+	}
+
+	for range count {
+		c := classAt(flat, off, bigWord)
+		for c != blank && classAt(flat, off, bigWord) == c {
+			off++
+		}
+		for off < len(flat) && classAt(flat, off, bigWord) == blank {
+			off++
+		}
+	}
+
+	// An operator stops at the end of the line rather than the start of the next
+	pos := e.position(off)
+	if op != 0 && pos.Row > e.cursor.Row && pos.Col == 0 {
+		pos = Position{pos.Row - 1, len(e.lines[pos.Row-1])}
+	}
+	return target{pos: pos}, true
+}
+
+// wordEnd returns the offset of the end of the word after off
+func wordEnd(flat []rune, off int, bigWord bool) int {
+	off++
+	for off < len(flat) && classAt(flat, off, bigWord) == blank {
+		off++
+	}
+	if off >= len(flat) {
+		return max(0, len(flat)-1)
+	}
+	c := classAt(flat, off, bigWord)
+	for off+1 < len(flat) && classAt(flat, off+1, bigWord) == c {
+		off++
+	}
+	return off
+}
+
+// wordStart returns the offset of the start of the word before off
+func wordStart(flat []rune, off int, bigWord bool) int {
+	off--
+	for off > 0 && classAt(flat, off, bigWord) == blank {
+		off--
+	}
+	if off <= 0 {
+		return 0
+	}
+	c := classAt(flat, off, bigWord)
+	for off > 0 && classAt(flat, off-1, bigWord) == c {
+		off--
+	}
+	return off
+}
+
+// Character classes separating words
+const (
+	blank = iota
+	keyword
+	punctuation
+)
+
+// class returns the word class of r. Every non-blank character is part of a
+// WORD, while words are runs of keyword characters or of punctuation.
+func class(r rune, bigWord bool) int {
+	switch {
+	case unicode.IsSpace(r):
+		return blank
+	case bigWord, r == '_', unicode.IsLetter(r), unicode.IsDigit(r):
+		return keyword
+	}
+	return punctuation
+}
+
+// classAt returns the word class at an offset, treating the end as blank
+func classAt(flat []rune, off int, bigWord bool) int {
+	if off >= len(flat) {
+		return blank
+	}
+	return class(flat[off], bigWord)
+}
+
+// bracketOpen and bracketClose pair up the brackets % and text objects use
+const (
+	bracketOpen  = "([{<"
+	bracketClose = ")]}>"
+)
+
+// matchBracket finds the bracket matching the first one at or after the cursor on its line
+func (e *Engine) matchBracket() (target, bool) {
+	line := e.lines[e.cursor.Row]
+	for col := e.cursor.Col; col < len(line); col++ {
+		r := line[col]
+		if i := strings.IndexRune(bracketOpen[:3], r); i >= 0 {
+			off, ok := e.findClose(e.offset(Position{e.cursor.Row, col}), r, rune(bracketClose[i]))
+			return target{pos: e.position(off), inclusive: true}, ok
+		}
+		if i := strings.IndexRune(bracketClose[:3], r); i >= 0 {
+			off, ok := e.findOpen(e.offset(Position{e.cursor.Row, col}), rune(bracketOpen[i]), r)
+			return target{pos: e.position(off), inclusive: true}, ok
+		}
+	}
+	return target{}, false
+}
+
+// findClose returns the offset of the bracket closing the one at off
+func (e *Engine) findClose(off int, open, close rune) (int, bool) {
+	flat := e.flatten()
+	depth := 0
+	for i := off; i < len(flat); i++ {
+		switch flat[i] {
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// findOpen returns the offset of the open bracket at off or enclosing it
+func (e *Engine) findOpen(off int, open, close rune) (int, bool) {
+	flat := e.flatten()
+	if off < len(flat) && flat[off] == open {
+		return off, true
+	}
+	return scanOpen(flat, off-1, open, close)
+}
+
+// scanOpen searches backwards from off for an unmatched open bracket
+func scanOpen(flat []rune, off int, open, close rune) (int, bool) {
+	depth := 0
+	for i := min(off, len(flat)-1); i >= 0; i-- {
+		switch flat[i] {
+		case close:
+			depth++
+		case open:
+			if depth == 0 {
+				return i, true
+			}
+			depth--
+		}
+	}
+	return 0, false
+}
+
+// textObject returns the offsets of a text object around the cursor
+func (e *Engine) textObject(object string, count int) (int, int, bool) {
+	around := object[0] == 'a'
+	switch kind := rune(object[1]); kind {
+	case 'w', 'W':
+		return e.wordObject(kind == 'W', around, count)
+	case '"', '\'', '`':
+		return e.quoteObject(kind, around)
+	default:
+		delims := map[rune]int{'(': 0, ')': 0, 'b': 0, '[': 1, ']': 1, '{': 2, '}': 2, 'B': 2, '<': 3, '>': 3}
+		i := delims[kind]
+		return e.bracketObject(rune(bracketOpen[i]), rune(bracketClose[i]), around, count)
+	}
+}
+
+// wordObject selects count words around the cursor, with their trailing blanks for aw
+func (e *Engine) wordObject(bigWord, around bool, count int) (int, int, bool) {
+	line := e.lines[e.cursor.Row]
+	if len(line) == 0 {
+		return 0, 0, false
+	}
+	base := e.offset(Position{e.cursor.Row, 0})
+	classOf := func(i int) int { return class(line[i], bigWord) }
+
+	start, end := e.cursor.Col, e.cursor.Col
+	for start > 0 && classOf(start-1) == classOf(e.cursor.Col) {
+		start--
+	}
+	for range count {
+		c := classOf(end)
+		for end < len(line) && classOf(end) == c {
+			end++
+		}
+		if around && end < len(line) && c != blank {
+			for end < len(line) && classOf(end) == blank {
+				end++
+			}
+		}
+		if end >= len(line) {
+			break
+		}
+	}
+	// aw at the end of a line takes the blanks before the word instead
+	if around && end == len(line) && classOf(end-1) != blank {
+		for start > 0 && classOf(start-1) == blank {
+			start--
+		}
+	}
+	return base + start, base + end, true
+}
+
+// quoteObject selects the quoted string on the cursor's line that contains or follows the cursor
+func (e *Engine) quoteObject(quote rune, around bool) (int, int, bool) {
+	line := e.lines[e.cursor.Row]
+	base := e.offset(Position{e.cursor.Row, 0})
+
+	var quotes []int
+	for i, r := range line {
+		if r == quote && (i == 0 || line[i-1] != '\\') {
+			quotes = append(quotes, i)
+		}
+	}
+	for i := 0; i+1 < len(quotes); i += 2 {
+		open, close := quotes[i], quotes[i+1]
+		if e.cursor.Col > close {
+			continue
+		}
+		if around {
+			return base + open, base + close + 1, true
+		}
+		return base + open + 1, base + close, true
+	}
+	return 0, 0, false
+}
+
+// bracketObject selects the text inside the count-th enclosing pair of brackets
+func (e *Engine) bracketObject(open, close rune, around bool, count int) (int, int, bool) {
+	start, ok := e.findOpen(e.offset(e.cursor), open, close)
+	for range count - 1 {
+		if !ok {
+			break
+		}
+		start, ok = scanOpen(e.flatten(), start-1, open, close)
+	}
+	if !ok {
+		return 0, 0, false
+	}
+	end, ok := e.findClose(start, open, close)
+	if !ok {
+		return 0, 0, false
+	}
+	if around {
+		return start, end + 1, true
+	}
+	return start + 1, end, true
+}
@@ -0,0 +1,138 @@
+package vim
+
+import "strings"
+
+// parseStatus tells whether pending keys form a complete command
+type parseStatus int
+
+const (
+	incomplete parseStatus = iota
+	complete
+	invalid
+)
+
+// command is a parsed Normal mode command
+type command struct {
+	count    int    // Count typed before the command, 0 if none
+	op       rune   // Operator d, c or y, 0 if none
+	opCount  int    // Count typed after the operator, 0 if none
+	action   string // Motion or simple command, e.g. "w", "gg" or "x"
+	arg      rune   // Character argument of f, t, F, T and r
+	object   string // Text object, e.g. "iw" or "a("
+	linewise bool   // Doubled operator such as dd
+}
+
+// motionKeys are the motions taking no argument
+var motionKeys = []string{"h", "j", "k", "l", "w", "W", "b", "B", "e", "E", "0", "^", "$", "G", "%"}
+
+// simpleKeys are the commands that are complete on their own
+const simpleKeys = "xXDCsSYpPiaIAoOJ~u"
+
+// objectKeys are the delimiters text objects accept after i or a
+const objectKeys = "wW()b{}B[]<>\"'`"
+
+// parse reads a command from keys
+func parse(keys []string) (command, parseStatus) {
+	var c command
+	i := 0
+	next := func() (string, bool) {
+		if i >= len(keys) {
+			return "", false
+		}
+		i++
+		return keys[i-1], true
+	}
+	readCount := func() int {
+		n := 0
+		for i < len(keys) && len(keys[i]) == 1 && keys[i][0] >= '0' && keys[i][0] <= '9' {
+			// A leading 0 is the motion to the start of the line
+			if n == 0 && keys[i] == "0" {
+				break
+			}
+			n = n*10 + int(keys[i][0]-'0')
+			i++
+		}
+		return n
+	}
+
+	c.count = readCount()
+	k, ok := next()
+	if !ok {
+		return c, incomplete
+	}
+
+	switch {
+	case k == "d" || k == "c" || k == "y":
+		c.op = rune(k[0])
+		c.opCount = readCount()
+		k2, ok := next()
+		if !ok {
+			return c, incomplete
+		}
+		if k2 == k {
+			c.linewise = true
+			return c, complete
+		}
+		if k2 == "i" || k2 == "a" {
+			obj, ok := next()
+			if !ok {
+				return c, incomplete
+			}
+			if len(obj) != 1 || !strings.Contains(objectKeys, obj) {
+				return c, invalid
+			}
+			c.object = k2 + obj
+			return c, complete
+		}
+		return parseMotion(c, k2, next)
+	case len(k) == 1 && strings.Contains(simpleKeys, k):
+		c.action = k
+		return c, complete
+	case k == "r":
+		arg, ok := next()
+		if !ok {
+			return c, incomplete
+		}
+		r, single := keyRune(arg)
+		if !single {
+			return c, invalid
+		}
+		c.action, c.arg = k, r
+		return c, complete
+	}
+	return parseMotion(c, k, next)
+}
+
+// parseMotion reads a motion starting with key k
+func parseMotion(c command, k string, next func() (string, bool)) (command, parseStatus) {
+	switch k {
+	case "f", "F", "t", "T":
+		arg, ok := next()
+		if !ok {
+			return c, incomplete
+		}
+		r, single := keyRune(arg)
+		if !single {
+			return c, invalid
+		}
+		c.action, c.arg = k, r
+		return c, complete
+	case "g":
+		k2, ok := next()
+		if !ok {
+			return c, incomplete
+		}
+		if k2 != "g" {
+			return c, invalid
+		}
+		c.action = "gg"
+		return c, complete
+	}
+	for _, m := range motionKeys {
+		if k == m {
+			c.action = k
+			return c, complete
+		}
+	}
+	return c, invalid
+}
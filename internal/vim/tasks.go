@@ -0,0 +1,254 @@
+package vim
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Task is an edit to perform on a small buffer with as few keystrokes as possible
+type Task struct {
+	Prompt     string
+	Lines      []string  // Starting buffer
+	Cursor     Position  // Starting cursor
+	Want       []string  // Buffer once the edit is done
+	WantCursor *Position // Where the cursor must end up, for motion tasks
+	Solution   []string  // Shortest known key sequence
+}
+
+// Optimal returns the fewest keystrokes known to complete the task
+func (t Task) Optimal() int {
+	return len(t.Solution)
+}
+
+// Engine returns an engine set up with the task's starting buffer
+func (t Task) Engine() *Engine {
+	return New(t.Lines, t.Cursor)
+}
+
+// Done reports whether the engine's buffer matches the task's goal. The edit
+// must be finished in Normal mode, so tasks that insert text end with esc.
+func (t Task) Done(e *Engine) bool {
+	if e.Mode() != Normal || e.Pending() != "" || !slices.Equal(e.Lines(), t.Want) {
+		return false
+	}
+	return t.WantCursor == nil || e.Cursor() == *t.WantCursor
+}
+
+// template builds a task from identifiers drawn with pick
+type template func(pick func() string) Task
+
+// templates are the tasks sessions draw from
+var templates = []template{
+	func(pick func() string) Task {
+		line := "call " + pick() + "(" + pick() + ", " + pick() + ")"
+		end := Position{0, len([]rune(line)) - 1}
+		return Task{
+			Prompt:     "move to the end of the line",
+			Lines:      []string{line},
+			Want:       []string{line},
+			WantCursor: &end,
+			Solution:   keys("$"),
+		}
+	},
+	func(pick func() string) Task {
+		name := pick()
+		line := "call " + name + "(" + pick() + ")"
+		paren := Position{0, len([]rune("call " + name))}
+		return Task{
+			Prompt:     "jump to the opening paren",
+			Lines:      []string{line},
+			Want:       []string{line},
+			WantCursor: &paren,
+			Solution:   keys("f("),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		line := "if " + a + "(" + b + "[" + c + "])"
+		close := Position{0, len([]rune(line)) - 1}
+		return Task{
+			Prompt:     "jump to the paren matching the first one",
+			Lines:      []string{line},
+			Cursor:     Position{0, len([]rune("if " + a))},
+			Want:       []string{line},
+			WantCursor: &close,
+			Solution:   keys("%"),
+		}
+	},
+	func(pick func() string) Task {
+		lines := []string{pick(), pick(), pick(), pick()}
+		last := Position{3, 0}
+		return Task{
+			Prompt:     "go to the last line",
+			Lines:      lines,
+			Want:       lines,
+			WantCursor: &last,
+			Solution:   keys("G"),
+		}
+	},
+	func(pick func() string) Task {
+		name, a, b := pick(), pick(), pick()
+		return Task{
+			Prompt:   "delete everything inside the parens",
+			Lines:    []string{"call " + name + "(" + a + ", " + b + ")"},
+			Cursor:   Position{0, len([]rune("call "+name+"(")) + 1},
+			Want:     []string{"call " + name + "()"},
+			Solution: keys("di("),
+		}
+	},
+	func(pick func() string) Task {
+		a, b := pick(), pick()
+		return Task{
+			Prompt:   "delete everything inside the quotes",
+			Lines:    []string{"echo \"" + a + " " + b + "\""},
+			Cursor:   Position{0, 7},
+			Want:     []string{"echo \"\""},
+			Solution: keys("di\""),
+		}
+	},
+	func(pick func() string) Task {
+		name, old, repl := pick(), pick(), pick()
+		return Task{
+			Prompt:   "change the word under the cursor to \"" + repl + "\"",
+			Lines:    []string{"let " + name + " = " + old},
+			Cursor:   Position{0, len([]rune("let "+name+" = ")) + 1},
+			Want:     []string{"let " + name + " = " + repl},
+			Solution: append(keys("ciw"+repl), "esc"),
+		}
+	},
+	func(pick func() string) Task {
+		name, a, b, repl := pick(), pick(), pick(), pick()
+		return Task{
+			Prompt:   "replace the list items with \"" + repl + "\"",
+			Lines:    []string{"let " + name + " = [" + a + ", " + b + "]"},
+			Cursor:   Position{0, len([]rune("let " + name + " = ["))},
+			Want:     []string{"let " + name + " = [" + repl + "]"},
+			Solution: append(keys("ci["+repl), "esc"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		return Task{
+			Prompt:   "delete the middle line",
+			Lines:    []string{a, b, c},
+			Cursor:   Position{1, 0},
+			Want:     []string{a, c},
+			Solution: keys("dd"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		return Task{
+			Prompt:   "delete from the cursor to the end of the line",
+			Lines:    []string{"echo " + a + " " + b + " " + c},
+			Cursor:   Position{0, len([]rune("echo "+a)) + 1},
+			Want:     []string{"echo " + a + " "},
+			Solution: keys("D"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		return Task{
+			Prompt:   "delete the next two words",
+			Lines:    []string{"echo " + a + " " + b + " " + c},
+			Cursor:   Position{0, 5},
+			Want:     []string{"echo " + c},
+			Solution: keys("d2w"),
+		}
+	},
+	func(pick func() string) Task {
+		name, a := pick(), pick()
+		return Task{
+			Prompt:   "delete the stray comma",
+			Lines:    []string{"call " + name + "(" + a + ",)"},
+			Cursor:   Position{0, len([]rune("call " + name + "(" + a))},
+			Want:     []string{"call " + name + "(" + a + ")"},
+			Solution: keys("x"),
+		}
+	},
+	func(pick func() string) Task {
+		name, value := pick(), pick()
+		return Task{
+			Prompt:   "append a semicolon to the line",
+			Lines:    []string{"let " + name + " = " + value},
+			Want:     []string{"let " + name + " = " + value + ";"},
+			Solution: append(keys("A;"), "esc"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		return Task{
+			Prompt:   "add a line \"" + c + "\" below the cursor",
+			Lines:    []string{a, b},
+			Want:     []string{a, c, b},
+			Solution: append(keys("o"+c), "esc"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b, c := pick(), pick(), pick()
+		return Task{
+			Prompt:   "swap the first two lines",
+			Lines:    []string{a, b, c},
+			Want:     []string{b, a, c},
+			Solution: keys("ddp"),
+		}
+	},
+	func(pick func() string) Task {
+		a, b := pick(), pick()
+		return Task{
+			Prompt:   "duplicate the first line",
+			Lines:    []string{a, b},
+			Want:     []string{a, a, b},
+			Solution: keys("Yp"),
+		}
+	},
+}
+
+// Tasks draws count tasks in random order, filling them with identifiers taken
+// from words, typically the Vimscript language pack
+func Tasks(words []string, count int, rng *rand.Rand) []Task {
+	var identifiers []string
+	for _, w := range words {
+		if len(w) >= 3 && len(w) <= 8 && isIdentifier(w) && !slices.Contains(identifiers, w) {
+			identifiers = append(identifiers, w)
+		}
+	}
+	if len(identifiers) < 8 {
+		identifiers = append(identifiers, "foo", "bar", "baz", "name", "value", "items", "count", "result")
+	}
+
+	order := rng.Perm(len(templates))
+	tasks := make([]Task, 0, count)
+	for i := range count {
+		// Identifiers within a task are distinct so that the goal is unambiguous
+		used := map[string]bool{}
+		pick := func() string {
+			for {
+				w := identifiers[rng.Intn(len(identifiers))]
+				if !used[w] || len(used) >= len(identifiers) {
+					used[w] = true
+					return w
+				}
+			}
+		}
+		tasks = append(tasks, templates[order[i%len(order)]](pick))
+	}
+	return tasks
+}
+
+// keys splits typed text into single-character keys
+func keys(text string) []string {
+	return strings.Split(text, "")
+}
+
+// isIdentifier reports whether w is made only of lowercase letters
+func isIdentifier(w string) bool {
+	for _, r := range w {
+		if !unicode.IsLower(r) {
+			return false
+		}
+	}
+	return true
+}